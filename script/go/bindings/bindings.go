@@ -0,0 +1,1846 @@
+// Code generated via abigen V2 - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = bytes.Equal
+	_ = errors.New
+	_ = big.NewInt
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = abi.ConvertType
+)
+
+// Identifier is an auto generated low-level Go binding around an user-defined struct.
+type Identifier struct {
+	Origin      common.Address
+	BlockNumber *big.Int
+	LogIndex    *big.Int
+	Timestamp   *big.Int
+	ChainId     *big.Int
+}
+
+// CrossL2InboxMetaData contains all meta data concerning the CrossL2Inbox contract.
+var CrossL2InboxMetaData = bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"calculateChecksum\",\"inputs\":[{\"name\":\"_id\",\"type\":\"tuple\",\"components\":[{\"name\":\"origin\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"blockNumber\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"logIndex\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"timestamp\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"chainId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"internalType\":\"structIdentifier\"},{\"name\":\"_msgHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[{\"name\":\"checksum_\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"stateMutability\":\"pure\"},{\"type\":\"function\",\"name\":\"validateMessage\",\"inputs\":[{\"name\":\"_id\",\"type\":\"tuple\",\"components\":[{\"name\":\"origin\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"blockNumber\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"logIndex\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"timestamp\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"chainId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"internalType\":\"structIdentifier\"},{\"name\":\"_msgHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"version\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"string\",\"internalType\":\"string\"}],\"stateMutability\":\"view\"},{\"type\":\"event\",\"name\":\"ExecutingMessage\",\"inputs\":[{\"name\":\"msgHash\",\"type\":\"bytes32\",\"indexed\":true,\"internalType\":\"bytes32\"},{\"name\":\"id\",\"type\":\"tuple\",\"components\":[{\"name\":\"origin\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"blockNumber\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"logIndex\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"timestamp\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"chainId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"indexed\":false,\"internalType\":\"structIdentifier\"}],\"anonymous\":false},{\"type\":\"error\",\"name\":\"BlockNumberTooHigh\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"LogIndexTooHigh\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"NoExecutingDeposits\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"NotInAccessList\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"TimestampTooHigh\",\"inputs\":[]}]",
+	ID:  "CrossL2Inbox",
+}
+
+// CrossL2Inbox is an auto generated Go binding around an Ethereum contract.
+type CrossL2Inbox struct {
+	abi abi.ABI
+}
+
+// NewCrossL2Inbox creates a new instance of CrossL2Inbox.
+func NewCrossL2Inbox() *CrossL2Inbox {
+	parsed, err := CrossL2InboxMetaData.ParseABI()
+	if err != nil {
+		panic(errors.New("invalid ABI: " + err.Error()))
+	}
+	return &CrossL2Inbox{abi: *parsed}
+}
+
+// Instance creates a wrapper for a deployed contract instance at the given address.
+// Use this to create the instance object passed to abigen v2 library functions Call, Transact, etc.
+func (c *CrossL2Inbox) Instance(backend bind.ContractBackend, addr common.Address) *bind.BoundContract {
+	return bind.NewBoundContract(addr, c.abi, backend, backend, backend)
+}
+
+// PackCalculateChecksum is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x331b637f.
+//
+// Solidity: function calculateChecksum((address,uint256,uint256,uint256,uint256) _id, bytes32 _msgHash) pure returns(bytes32 checksum_)
+func (crossL2Inbox *CrossL2Inbox) PackCalculateChecksum(id Identifier, msgHash [32]byte) []byte {
+	enc, err := crossL2Inbox.abi.Pack("calculateChecksum", id, msgHash)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackCalculateChecksum is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x331b637f.
+//
+// Solidity: function calculateChecksum((address,uint256,uint256,uint256,uint256) _id, bytes32 _msgHash) pure returns(bytes32 checksum_)
+func (crossL2Inbox *CrossL2Inbox) UnpackCalculateChecksum(data []byte) ([32]byte, error) {
+	out, err := crossL2Inbox.abi.Unpack("calculateChecksum", data)
+	if err != nil {
+		return *new([32]byte), err
+	}
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+	return out0, err
+}
+
+// PackValidateMessage is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0xab4d6f75.
+//
+// Solidity: function validateMessage((address,uint256,uint256,uint256,uint256) _id, bytes32 _msgHash) returns()
+func (crossL2Inbox *CrossL2Inbox) PackValidateMessage(id Identifier, msgHash [32]byte) []byte {
+	enc, err := crossL2Inbox.abi.Pack("validateMessage", id, msgHash)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// PackVersion is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x54fd4d50.
+//
+// Solidity: function version() view returns(string)
+func (crossL2Inbox *CrossL2Inbox) PackVersion() []byte {
+	enc, err := crossL2Inbox.abi.Pack("version")
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackVersion is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x54fd4d50.
+//
+// Solidity: function version() view returns(string)
+func (crossL2Inbox *CrossL2Inbox) UnpackVersion(data []byte) (string, error) {
+	out, err := crossL2Inbox.abi.Unpack("version", data)
+	if err != nil {
+		return *new(string), err
+	}
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+	return out0, err
+}
+
+// CrossL2InboxExecutingMessage represents a ExecutingMessage event raised by the CrossL2Inbox contract.
+type CrossL2InboxExecutingMessage struct {
+	MsgHash [32]byte
+	Id      Identifier
+	Raw     *types.Log // Blockchain specific contextual infos
+}
+
+const CrossL2InboxExecutingMessageEventName = "ExecutingMessage"
+
+// ContractEventName returns the user-defined event name.
+func (CrossL2InboxExecutingMessage) ContractEventName() string {
+	return CrossL2InboxExecutingMessageEventName
+}
+
+// UnpackExecutingMessageEvent is the Go binding that unpacks the event data emitted
+// by contract.
+//
+// Solidity: event ExecutingMessage(bytes32 indexed msgHash, (address,uint256,uint256,uint256,uint256) id)
+func (crossL2Inbox *CrossL2Inbox) UnpackExecutingMessageEvent(log *types.Log) (*CrossL2InboxExecutingMessage, error) {
+	event := "ExecutingMessage"
+	if log.Topics[0] != crossL2Inbox.abi.Events[event].ID {
+		return nil, errors.New("event signature mismatch")
+	}
+	out := new(CrossL2InboxExecutingMessage)
+	if len(log.Data) > 0 {
+		if err := crossL2Inbox.abi.UnpackIntoInterface(out, event, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range crossL2Inbox.abi.Events[event].Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopics(out, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	out.Raw = log
+	return out, nil
+}
+
+// UnpackError attempts to decode the provided error data using user-defined
+// error definitions.
+func (crossL2Inbox *CrossL2Inbox) UnpackError(raw []byte) (any, error) {
+	if bytes.Equal(raw[:4], crossL2Inbox.abi.Errors["BlockNumberTooHigh"].ID.Bytes()[:4]) {
+		return crossL2Inbox.UnpackBlockNumberTooHighError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], crossL2Inbox.abi.Errors["LogIndexTooHigh"].ID.Bytes()[:4]) {
+		return crossL2Inbox.UnpackLogIndexTooHighError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], crossL2Inbox.abi.Errors["NoExecutingDeposits"].ID.Bytes()[:4]) {
+		return crossL2Inbox.UnpackNoExecutingDepositsError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], crossL2Inbox.abi.Errors["NotInAccessList"].ID.Bytes()[:4]) {
+		return crossL2Inbox.UnpackNotInAccessListError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], crossL2Inbox.abi.Errors["TimestampTooHigh"].ID.Bytes()[:4]) {
+		return crossL2Inbox.UnpackTimestampTooHighError(raw[4:])
+	}
+	return nil, errors.New("Unknown error")
+}
+
+// CrossL2InboxBlockNumberTooHigh represents a BlockNumberTooHigh error raised by the CrossL2Inbox contract.
+type CrossL2InboxBlockNumberTooHigh struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error BlockNumberTooHigh()
+func CrossL2InboxBlockNumberTooHighErrorID() common.Hash {
+	return common.HexToHash("0xd1f79e8226da71838f75575e404795d37cd62084f2960e09309077c61c1ab901")
+}
+
+// UnpackBlockNumberTooHighError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error BlockNumberTooHigh()
+func (crossL2Inbox *CrossL2Inbox) UnpackBlockNumberTooHighError(raw []byte) (*CrossL2InboxBlockNumberTooHigh, error) {
+	out := new(CrossL2InboxBlockNumberTooHigh)
+	if err := crossL2Inbox.abi.UnpackIntoInterface(out, "BlockNumberTooHigh", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CrossL2InboxLogIndexTooHigh represents a LogIndexTooHigh error raised by the CrossL2Inbox contract.
+type CrossL2InboxLogIndexTooHigh struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error LogIndexTooHigh()
+func CrossL2InboxLogIndexTooHighErrorID() common.Hash {
+	return common.HexToHash("0x94338eba48e3769cffa46c4de788addb6ddf64f9900e4dc70ff744cc9e00a309")
+}
+
+// UnpackLogIndexTooHighError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error LogIndexTooHigh()
+func (crossL2Inbox *CrossL2Inbox) UnpackLogIndexTooHighError(raw []byte) (*CrossL2InboxLogIndexTooHigh, error) {
+	out := new(CrossL2InboxLogIndexTooHigh)
+	if err := crossL2Inbox.abi.UnpackIntoInterface(out, "LogIndexTooHigh", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CrossL2InboxNoExecutingDeposits represents a NoExecutingDeposits error raised by the CrossL2Inbox contract.
+type CrossL2InboxNoExecutingDeposits struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error NoExecutingDeposits()
+func CrossL2InboxNoExecutingDepositsErrorID() common.Hash {
+	return common.HexToHash("0x753f10724437d3f2e704cac80ba69262fd0fc750ccdd983813c9e62294b87ec4")
+}
+
+// UnpackNoExecutingDepositsError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error NoExecutingDeposits()
+func (crossL2Inbox *CrossL2Inbox) UnpackNoExecutingDepositsError(raw []byte) (*CrossL2InboxNoExecutingDeposits, error) {
+	out := new(CrossL2InboxNoExecutingDeposits)
+	if err := crossL2Inbox.abi.UnpackIntoInterface(out, "NoExecutingDeposits", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CrossL2InboxNotInAccessList represents a NotInAccessList error raised by the CrossL2Inbox contract.
+type CrossL2InboxNotInAccessList struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error NotInAccessList()
+func CrossL2InboxNotInAccessListErrorID() common.Hash {
+	return common.HexToHash("0xe3c0081644c29bc6a7f74b5e3d40cb8e0810209d54843f97b5e2c1cf85ab218a")
+}
+
+// UnpackNotInAccessListError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error NotInAccessList()
+func (crossL2Inbox *CrossL2Inbox) UnpackNotInAccessListError(raw []byte) (*CrossL2InboxNotInAccessList, error) {
+	out := new(CrossL2InboxNotInAccessList)
+	if err := crossL2Inbox.abi.UnpackIntoInterface(out, "NotInAccessList", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CrossL2InboxTimestampTooHigh represents a TimestampTooHigh error raised by the CrossL2Inbox contract.
+type CrossL2InboxTimestampTooHigh struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error TimestampTooHigh()
+func CrossL2InboxTimestampTooHighErrorID() common.Hash {
+	return common.HexToHash("0x596a19a9a2b29e2ac0ac569cc8987acee0dee3d264b672b7520c1eb7f90ce9e1")
+}
+
+// UnpackTimestampTooHighError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error TimestampTooHigh()
+func (crossL2Inbox *CrossL2Inbox) UnpackTimestampTooHighError(raw []byte) (*CrossL2InboxTimestampTooHigh, error) {
+	out := new(CrossL2InboxTimestampTooHigh)
+	if err := crossL2Inbox.abi.UnpackIntoInterface(out, "TimestampTooHigh", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GasTankMetaData contains all meta data concerning the GasTank contract.
+var GasTankMetaData = bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"GAS_PRICE_ORACLE\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"contractIGasPriceOracle\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"MAX_DEPOSIT\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"MESSENGER\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"contractIL2ToL2CrossDomainMessenger\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"WITHDRAWAL_DELAY\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"authorizeClaim\",\"inputs\":[{\"name\":\"_messageHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"authorizedMessages\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"balanceOf\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"claim\",\"inputs\":[{\"name\":\"_id\",\"type\":\"tuple\",\"components\":[{\"name\":\"origin\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"blockNumber\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"logIndex\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"timestamp\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"chainId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"internalType\":\"structIdentifier\"},{\"name\":\"_gasProvider\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_payload\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"claimOverhead\",\"inputs\":[{\"name\":\"_numHashes\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_baseFee\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_data\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"overhead_\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"claimed\",\"inputs\":[{\"name\":\"\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"decodeGasReceiptPayload\",\"inputs\":[{\"name\":\"_payload\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"messageHash_\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"relayer_\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"relayCost_\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"nestedMessageHashes_\",\"type\":\"bytes32[]\",\"internalType\":\"bytes32[]\"}],\"stateMutability\":\"pure\"},{\"type\":\"function\",\"name\":\"deposit\",\"inputs\":[{\"name\":\"_to\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"finalizeWithdrawal\",\"inputs\":[{\"name\":\"_to\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"initiateWithdrawal\",\"inputs\":[{\"name\":\"_amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"relayMessage\",\"inputs\":[{\"name\":\"_id\",\"type\":\"tuple\",\"components\":[{\"name\":\"origin\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"blockNumber\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"logIndex\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"timestamp\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"chainId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"internalType\":\"structIdentifier\"},{\"name\":\"_sentMessage\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"relayCost_\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"nestedMessageHashes_\",\"type\":\"bytes32[]\",\"internalType\":\"bytes32[]\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"withdrawals\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"timestamp\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"event\",\"name\":\"AuthorizedClaims\",\"inputs\":[{\"name\":\"gasProvider\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"messageHashes\",\"type\":\"bytes32[]\",\"indexed\":false,\"internalType\":\"bytes32[]\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Claimed\",\"inputs\":[{\"name\":\"messageHash\",\"type\":\"bytes32\",\"indexed\":true,\"internalType\":\"bytes32\"},{\"name\":\"relayer\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"gasProvider\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"claimer\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"},{\"name\":\"relayCost\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"claimCost\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Deposit\",\"inputs\":[{\"name\":\"gasProvider\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"RelayedMessageGasReceipt\",\"inputs\":[{\"name\":\"messageHash\",\"type\":\"bytes32\",\"indexed\":true,\"internalType\":\"bytes32\"},{\"name\":\"relayer\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"relayCost\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"},{\"name\":\"nestedMessageHashes\",\"type\":\"bytes32[]\",\"indexed\":false,\"internalType\":\"bytes32[]\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"WithdrawalFinalized\",\"inputs\":[{\"name\":\"from\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"to\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"WithdrawalInitiated\",\"inputs\":[{\"name\":\"from\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"error\",\"name\":\"AlreadyClaimed\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InsufficientBalance\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidLength\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidOrigin\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidPayload\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"MaxDepositExceeded\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"MessageNotAuthorized\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"WithdrawPending\",\"inputs\":[]}]",
+	ID:  "GasTank",
+}
+
+// GasTank is an auto generated Go binding around an Ethereum contract.
+type GasTank struct {
+	abi abi.ABI
+}
+
+// NewGasTank creates a new instance of GasTank.
+func NewGasTank() *GasTank {
+	parsed, err := GasTankMetaData.ParseABI()
+	if err != nil {
+		panic(errors.New("invalid ABI: " + err.Error()))
+	}
+	return &GasTank{abi: *parsed}
+}
+
+// Instance creates a wrapper for a deployed contract instance at the given address.
+// Use this to create the instance object passed to abigen v2 library functions Call, Transact, etc.
+func (c *GasTank) Instance(backend bind.ContractBackend, addr common.Address) *bind.BoundContract {
+	return bind.NewBoundContract(addr, c.abi, backend, backend, backend)
+}
+
+// PackGASPRICEORACLE is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x6e70f808.
+//
+// Solidity: function GAS_PRICE_ORACLE() view returns(address)
+func (gasTank *GasTank) PackGASPRICEORACLE() []byte {
+	enc, err := gasTank.abi.Pack("GAS_PRICE_ORACLE")
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackGASPRICEORACLE is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x6e70f808.
+//
+// Solidity: function GAS_PRICE_ORACLE() view returns(address)
+func (gasTank *GasTank) UnpackGASPRICEORACLE(data []byte) (common.Address, error) {
+	out, err := gasTank.abi.Unpack("GAS_PRICE_ORACLE", data)
+	if err != nil {
+		return *new(common.Address), err
+	}
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	return out0, err
+}
+
+// PackMAXDEPOSIT is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0xdd5967c3.
+//
+// Solidity: function MAX_DEPOSIT() view returns(uint256)
+func (gasTank *GasTank) PackMAXDEPOSIT() []byte {
+	enc, err := gasTank.abi.Pack("MAX_DEPOSIT")
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackMAXDEPOSIT is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0xdd5967c3.
+//
+// Solidity: function MAX_DEPOSIT() view returns(uint256)
+func (gasTank *GasTank) UnpackMAXDEPOSIT(data []byte) (*big.Int, error) {
+	out, err := gasTank.abi.Unpack("MAX_DEPOSIT", data)
+	if err != nil {
+		return new(big.Int), err
+	}
+	out0 := abi.ConvertType(out[0], new(big.Int)).(*big.Int)
+	return out0, err
+}
+
+// PackMESSENGER is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x927ede2d.
+//
+// Solidity: function MESSENGER() view returns(address)
+func (gasTank *GasTank) PackMESSENGER() []byte {
+	enc, err := gasTank.abi.Pack("MESSENGER")
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackMESSENGER is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x927ede2d.
+//
+// Solidity: function MESSENGER() view returns(address)
+func (gasTank *GasTank) UnpackMESSENGER(data []byte) (common.Address, error) {
+	out, err := gasTank.abi.Unpack("MESSENGER", data)
+	if err != nil {
+		return *new(common.Address), err
+	}
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	return out0, err
+}
+
+// PackWITHDRAWALDELAY is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x0ebb172a.
+//
+// Solidity: function WITHDRAWAL_DELAY() view returns(uint256)
+func (gasTank *GasTank) PackWITHDRAWALDELAY() []byte {
+	enc, err := gasTank.abi.Pack("WITHDRAWAL_DELAY")
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackWITHDRAWALDELAY is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x0ebb172a.
+//
+// Solidity: function WITHDRAWAL_DELAY() view returns(uint256)
+func (gasTank *GasTank) UnpackWITHDRAWALDELAY(data []byte) (*big.Int, error) {
+	out, err := gasTank.abi.Unpack("WITHDRAWAL_DELAY", data)
+	if err != nil {
+		return new(big.Int), err
+	}
+	out0 := abi.ConvertType(out[0], new(big.Int)).(*big.Int)
+	return out0, err
+}
+
+// PackAuthorizeClaim is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0xb00cda24.
+//
+// Solidity: function authorizeClaim(bytes32 _messageHash) returns()
+func (gasTank *GasTank) PackAuthorizeClaim(messageHash [32]byte) []byte {
+	enc, err := gasTank.abi.Pack("authorizeClaim", messageHash)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// PackAuthorizedMessages is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x8932e9ca.
+//
+// Solidity: function authorizedMessages(address , bytes32 ) view returns(bool)
+func (gasTank *GasTank) PackAuthorizedMessages(arg0 common.Address, arg1 [32]byte) []byte {
+	enc, err := gasTank.abi.Pack("authorizedMessages", arg0, arg1)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackAuthorizedMessages is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x8932e9ca.
+//
+// Solidity: function authorizedMessages(address , bytes32 ) view returns(bool)
+func (gasTank *GasTank) UnpackAuthorizedMessages(data []byte) (bool, error) {
+	out, err := gasTank.abi.Unpack("authorizedMessages", data)
+	if err != nil {
+		return *new(bool), err
+	}
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+	return out0, err
+}
+
+// PackBalanceOf is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (gasTank *GasTank) PackBalanceOf(arg0 common.Address) []byte {
+	enc, err := gasTank.abi.Pack("balanceOf", arg0)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackBalanceOf is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (gasTank *GasTank) UnpackBalanceOf(data []byte) (*big.Int, error) {
+	out, err := gasTank.abi.Unpack("balanceOf", data)
+	if err != nil {
+		return new(big.Int), err
+	}
+	out0 := abi.ConvertType(out[0], new(big.Int)).(*big.Int)
+	return out0, err
+}
+
+// PackClaim is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x6695b622.
+//
+// Solidity: function claim((address,uint256,uint256,uint256,uint256) _id, address _gasProvider, bytes _payload) returns()
+func (gasTank *GasTank) PackClaim(id Identifier, gasProvider common.Address, payload []byte) []byte {
+	enc, err := gasTank.abi.Pack("claim", id, gasProvider, payload)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// PackClaimOverhead is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x10a739f9.
+//
+// Solidity: function claimOverhead(uint256 _numHashes, uint256 _baseFee, bytes _data) view returns(uint256 overhead_)
+func (gasTank *GasTank) PackClaimOverhead(numHashes *big.Int, baseFee *big.Int, data []byte) []byte {
+	enc, err := gasTank.abi.Pack("claimOverhead", numHashes, baseFee, data)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackClaimOverhead is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x10a739f9.
+//
+// Solidity: function claimOverhead(uint256 _numHashes, uint256 _baseFee, bytes _data) view returns(uint256 overhead_)
+func (gasTank *GasTank) UnpackClaimOverhead(data []byte) (*big.Int, error) {
+	out, err := gasTank.abi.Unpack("claimOverhead", data)
+	if err != nil {
+		return new(big.Int), err
+	}
+	out0 := abi.ConvertType(out[0], new(big.Int)).(*big.Int)
+	return out0, err
+}
+
+// PackClaimed is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0xcc3c0f06.
+//
+// Solidity: function claimed(bytes32 ) view returns(bool)
+func (gasTank *GasTank) PackClaimed(arg0 [32]byte) []byte {
+	enc, err := gasTank.abi.Pack("claimed", arg0)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackClaimed is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0xcc3c0f06.
+//
+// Solidity: function claimed(bytes32 ) view returns(bool)
+func (gasTank *GasTank) UnpackClaimed(data []byte) (bool, error) {
+	out, err := gasTank.abi.Unpack("claimed", data)
+	if err != nil {
+		return *new(bool), err
+	}
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+	return out0, err
+}
+
+// PackDecodeGasReceiptPayload is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x81e829f3.
+//
+// Solidity: function decodeGasReceiptPayload(bytes _payload) pure returns(bytes32 messageHash_, address relayer_, uint256 relayCost_, bytes32[] nestedMessageHashes_)
+func (gasTank *GasTank) PackDecodeGasReceiptPayload(payload []byte) []byte {
+	enc, err := gasTank.abi.Pack("decodeGasReceiptPayload", payload)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// DecodeGasReceiptPayloadOutput serves as a container for the return parameters of contract
+// method DecodeGasReceiptPayload.
+type DecodeGasReceiptPayloadOutput struct {
+	MessageHash         [32]byte
+	Relayer             common.Address
+	RelayCost           *big.Int
+	NestedMessageHashes [][32]byte
+}
+
+// UnpackDecodeGasReceiptPayload is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x81e829f3.
+//
+// Solidity: function decodeGasReceiptPayload(bytes _payload) pure returns(bytes32 messageHash_, address relayer_, uint256 relayCost_, bytes32[] nestedMessageHashes_)
+func (gasTank *GasTank) UnpackDecodeGasReceiptPayload(data []byte) (DecodeGasReceiptPayloadOutput, error) {
+	out, err := gasTank.abi.Unpack("decodeGasReceiptPayload", data)
+	outstruct := new(DecodeGasReceiptPayloadOutput)
+	if err != nil {
+		return *outstruct, err
+	}
+	outstruct.MessageHash = *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+	outstruct.Relayer = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.RelayCost = abi.ConvertType(out[2], new(big.Int)).(*big.Int)
+	outstruct.NestedMessageHashes = *abi.ConvertType(out[3], new([][32]byte)).(*[][32]byte)
+	return *outstruct, err
+
+}
+
+// PackDeposit is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0xf340fa01.
+//
+// Solidity: function deposit(address _to) payable returns()
+func (gasTank *GasTank) PackDeposit(to common.Address) []byte {
+	enc, err := gasTank.abi.Pack("deposit", to)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// PackFinalizeWithdrawal is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x4abf24cb.
+//
+// Solidity: function finalizeWithdrawal(address _to) returns()
+func (gasTank *GasTank) PackFinalizeWithdrawal(to common.Address) []byte {
+	enc, err := gasTank.abi.Pack("finalizeWithdrawal", to)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// PackInitiateWithdrawal is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x12edde5e.
+//
+// Solidity: function initiateWithdrawal(uint256 _amount) returns()
+func (gasTank *GasTank) PackInitiateWithdrawal(amount *big.Int) []byte {
+	enc, err := gasTank.abi.Pack("initiateWithdrawal", amount)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// PackRelayMessage is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x8d1d298f.
+//
+// Solidity: function relayMessage((address,uint256,uint256,uint256,uint256) _id, bytes _sentMessage) returns(uint256 relayCost_, bytes32[] nestedMessageHashes_)
+func (gasTank *GasTank) PackRelayMessage(id Identifier, sentMessage []byte) []byte {
+	enc, err := gasTank.abi.Pack("relayMessage", id, sentMessage)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// RelayMessageOutput serves as a container for the return parameters of contract
+// method RelayMessage.
+type RelayMessageOutput struct {
+	RelayCost           *big.Int
+	NestedMessageHashes [][32]byte
+}
+
+// UnpackRelayMessage is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x8d1d298f.
+//
+// Solidity: function relayMessage((address,uint256,uint256,uint256,uint256) _id, bytes _sentMessage) returns(uint256 relayCost_, bytes32[] nestedMessageHashes_)
+func (gasTank *GasTank) UnpackRelayMessage(data []byte) (RelayMessageOutput, error) {
+	out, err := gasTank.abi.Unpack("relayMessage", data)
+	outstruct := new(RelayMessageOutput)
+	if err != nil {
+		return *outstruct, err
+	}
+	outstruct.RelayCost = abi.ConvertType(out[0], new(big.Int)).(*big.Int)
+	outstruct.NestedMessageHashes = *abi.ConvertType(out[1], new([][32]byte)).(*[][32]byte)
+	return *outstruct, err
+
+}
+
+// PackWithdrawals is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x7a9262a2.
+//
+// Solidity: function withdrawals(address ) view returns(uint256 timestamp, uint256 amount)
+func (gasTank *GasTank) PackWithdrawals(arg0 common.Address) []byte {
+	enc, err := gasTank.abi.Pack("withdrawals", arg0)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// WithdrawalsOutput serves as a container for the return parameters of contract
+// method Withdrawals.
+type WithdrawalsOutput struct {
+	Timestamp *big.Int
+	Amount    *big.Int
+}
+
+// UnpackWithdrawals is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x7a9262a2.
+//
+// Solidity: function withdrawals(address ) view returns(uint256 timestamp, uint256 amount)
+func (gasTank *GasTank) UnpackWithdrawals(data []byte) (WithdrawalsOutput, error) {
+	out, err := gasTank.abi.Unpack("withdrawals", data)
+	outstruct := new(WithdrawalsOutput)
+	if err != nil {
+		return *outstruct, err
+	}
+	outstruct.Timestamp = abi.ConvertType(out[0], new(big.Int)).(*big.Int)
+	outstruct.Amount = abi.ConvertType(out[1], new(big.Int)).(*big.Int)
+	return *outstruct, err
+
+}
+
+// GasTankAuthorizedClaims represents a AuthorizedClaims event raised by the GasTank contract.
+type GasTankAuthorizedClaims struct {
+	GasProvider   common.Address
+	MessageHashes [][32]byte
+	Raw           *types.Log // Blockchain specific contextual infos
+}
+
+const GasTankAuthorizedClaimsEventName = "AuthorizedClaims"
+
+// ContractEventName returns the user-defined event name.
+func (GasTankAuthorizedClaims) ContractEventName() string {
+	return GasTankAuthorizedClaimsEventName
+}
+
+// UnpackAuthorizedClaimsEvent is the Go binding that unpacks the event data emitted
+// by contract.
+//
+// Solidity: event AuthorizedClaims(address indexed gasProvider, bytes32[] messageHashes)
+func (gasTank *GasTank) UnpackAuthorizedClaimsEvent(log *types.Log) (*GasTankAuthorizedClaims, error) {
+	event := "AuthorizedClaims"
+	if log.Topics[0] != gasTank.abi.Events[event].ID {
+		return nil, errors.New("event signature mismatch")
+	}
+	out := new(GasTankAuthorizedClaims)
+	if len(log.Data) > 0 {
+		if err := gasTank.abi.UnpackIntoInterface(out, event, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range gasTank.abi.Events[event].Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopics(out, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	out.Raw = log
+	return out, nil
+}
+
+// GasTankClaimed represents a Claimed event raised by the GasTank contract.
+type GasTankClaimed struct {
+	MessageHash [32]byte
+	Relayer     common.Address
+	GasProvider common.Address
+	Claimer     common.Address
+	RelayCost   *big.Int
+	ClaimCost   *big.Int
+	Raw         *types.Log // Blockchain specific contextual infos
+}
+
+const GasTankClaimedEventName = "Claimed"
+
+// ContractEventName returns the user-defined event name.
+func (GasTankClaimed) ContractEventName() string {
+	return GasTankClaimedEventName
+}
+
+// UnpackClaimedEvent is the Go binding that unpacks the event data emitted
+// by contract.
+//
+// Solidity: event Claimed(bytes32 indexed messageHash, address indexed relayer, address indexed gasProvider, address claimer, uint256 relayCost, uint256 claimCost)
+func (gasTank *GasTank) UnpackClaimedEvent(log *types.Log) (*GasTankClaimed, error) {
+	event := "Claimed"
+	if log.Topics[0] != gasTank.abi.Events[event].ID {
+		return nil, errors.New("event signature mismatch")
+	}
+	out := new(GasTankClaimed)
+	if len(log.Data) > 0 {
+		if err := gasTank.abi.UnpackIntoInterface(out, event, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range gasTank.abi.Events[event].Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopics(out, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	out.Raw = log
+	return out, nil
+}
+
+// GasTankDeposit represents a Deposit event raised by the GasTank contract.
+type GasTankDeposit struct {
+	GasProvider common.Address
+	Amount      *big.Int
+	Raw         *types.Log // Blockchain specific contextual infos
+}
+
+const GasTankDepositEventName = "Deposit"
+
+// ContractEventName returns the user-defined event name.
+func (GasTankDeposit) ContractEventName() string {
+	return GasTankDepositEventName
+}
+
+// UnpackDepositEvent is the Go binding that unpacks the event data emitted
+// by contract.
+//
+// Solidity: event Deposit(address indexed gasProvider, uint256 amount)
+func (gasTank *GasTank) UnpackDepositEvent(log *types.Log) (*GasTankDeposit, error) {
+	event := "Deposit"
+	if log.Topics[0] != gasTank.abi.Events[event].ID {
+		return nil, errors.New("event signature mismatch")
+	}
+	out := new(GasTankDeposit)
+	if len(log.Data) > 0 {
+		if err := gasTank.abi.UnpackIntoInterface(out, event, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range gasTank.abi.Events[event].Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopics(out, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	out.Raw = log
+	return out, nil
+}
+
+// GasTankRelayedMessageGasReceipt represents a RelayedMessageGasReceipt event raised by the GasTank contract.
+type GasTankRelayedMessageGasReceipt struct {
+	MessageHash         [32]byte
+	Relayer             common.Address
+	RelayCost           *big.Int
+	NestedMessageHashes [][32]byte
+	Raw                 *types.Log // Blockchain specific contextual infos
+}
+
+const GasTankRelayedMessageGasReceiptEventName = "RelayedMessageGasReceipt"
+
+// ContractEventName returns the user-defined event name.
+func (GasTankRelayedMessageGasReceipt) ContractEventName() string {
+	return GasTankRelayedMessageGasReceiptEventName
+}
+
+// UnpackRelayedMessageGasReceiptEvent is the Go binding that unpacks the event data emitted
+// by contract.
+//
+// Solidity: event RelayedMessageGasReceipt(bytes32 indexed messageHash, address indexed relayer, uint256 relayCost, bytes32[] nestedMessageHashes)
+func (gasTank *GasTank) UnpackRelayedMessageGasReceiptEvent(log *types.Log) (*GasTankRelayedMessageGasReceipt, error) {
+	event := "RelayedMessageGasReceipt"
+	if log.Topics[0] != gasTank.abi.Events[event].ID {
+		return nil, errors.New("event signature mismatch")
+	}
+	out := new(GasTankRelayedMessageGasReceipt)
+	if len(log.Data) > 0 {
+		if err := gasTank.abi.UnpackIntoInterface(out, event, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range gasTank.abi.Events[event].Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopics(out, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	out.Raw = log
+	return out, nil
+}
+
+// GasTankWithdrawalFinalized represents a WithdrawalFinalized event raised by the GasTank contract.
+type GasTankWithdrawalFinalized struct {
+	From   common.Address
+	To     common.Address
+	Amount *big.Int
+	Raw    *types.Log // Blockchain specific contextual infos
+}
+
+const GasTankWithdrawalFinalizedEventName = "WithdrawalFinalized"
+
+// ContractEventName returns the user-defined event name.
+func (GasTankWithdrawalFinalized) ContractEventName() string {
+	return GasTankWithdrawalFinalizedEventName
+}
+
+// UnpackWithdrawalFinalizedEvent is the Go binding that unpacks the event data emitted
+// by contract.
+//
+// Solidity: event WithdrawalFinalized(address indexed from, address indexed to, uint256 amount)
+func (gasTank *GasTank) UnpackWithdrawalFinalizedEvent(log *types.Log) (*GasTankWithdrawalFinalized, error) {
+	event := "WithdrawalFinalized"
+	if log.Topics[0] != gasTank.abi.Events[event].ID {
+		return nil, errors.New("event signature mismatch")
+	}
+	out := new(GasTankWithdrawalFinalized)
+	if len(log.Data) > 0 {
+		if err := gasTank.abi.UnpackIntoInterface(out, event, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range gasTank.abi.Events[event].Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopics(out, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	out.Raw = log
+	return out, nil
+}
+
+// GasTankWithdrawalInitiated represents a WithdrawalInitiated event raised by the GasTank contract.
+type GasTankWithdrawalInitiated struct {
+	From   common.Address
+	Amount *big.Int
+	Raw    *types.Log // Blockchain specific contextual infos
+}
+
+const GasTankWithdrawalInitiatedEventName = "WithdrawalInitiated"
+
+// ContractEventName returns the user-defined event name.
+func (GasTankWithdrawalInitiated) ContractEventName() string {
+	return GasTankWithdrawalInitiatedEventName
+}
+
+// UnpackWithdrawalInitiatedEvent is the Go binding that unpacks the event data emitted
+// by contract.
+//
+// Solidity: event WithdrawalInitiated(address indexed from, uint256 amount)
+func (gasTank *GasTank) UnpackWithdrawalInitiatedEvent(log *types.Log) (*GasTankWithdrawalInitiated, error) {
+	event := "WithdrawalInitiated"
+	if log.Topics[0] != gasTank.abi.Events[event].ID {
+		return nil, errors.New("event signature mismatch")
+	}
+	out := new(GasTankWithdrawalInitiated)
+	if len(log.Data) > 0 {
+		if err := gasTank.abi.UnpackIntoInterface(out, event, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range gasTank.abi.Events[event].Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopics(out, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	out.Raw = log
+	return out, nil
+}
+
+// UnpackError attempts to decode the provided error data using user-defined
+// error definitions.
+func (gasTank *GasTank) UnpackError(raw []byte) (any, error) {
+	if bytes.Equal(raw[:4], gasTank.abi.Errors["AlreadyClaimed"].ID.Bytes()[:4]) {
+		return gasTank.UnpackAlreadyClaimedError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], gasTank.abi.Errors["InsufficientBalance"].ID.Bytes()[:4]) {
+		return gasTank.UnpackInsufficientBalanceError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], gasTank.abi.Errors["InvalidLength"].ID.Bytes()[:4]) {
+		return gasTank.UnpackInvalidLengthError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], gasTank.abi.Errors["InvalidOrigin"].ID.Bytes()[:4]) {
+		return gasTank.UnpackInvalidOriginError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], gasTank.abi.Errors["InvalidPayload"].ID.Bytes()[:4]) {
+		return gasTank.UnpackInvalidPayloadError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], gasTank.abi.Errors["MaxDepositExceeded"].ID.Bytes()[:4]) {
+		return gasTank.UnpackMaxDepositExceededError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], gasTank.abi.Errors["MessageNotAuthorized"].ID.Bytes()[:4]) {
+		return gasTank.UnpackMessageNotAuthorizedError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], gasTank.abi.Errors["WithdrawPending"].ID.Bytes()[:4]) {
+		return gasTank.UnpackWithdrawPendingError(raw[4:])
+	}
+	return nil, errors.New("Unknown error")
+}
+
+// GasTankAlreadyClaimed represents a AlreadyClaimed error raised by the GasTank contract.
+type GasTankAlreadyClaimed struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error AlreadyClaimed()
+func GasTankAlreadyClaimedErrorID() common.Hash {
+	return common.HexToHash("0x646cf558a545d59f8a09cbf8a0eb8a9332f1d17834843b20fc8d154839dc46d7")
+}
+
+// UnpackAlreadyClaimedError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error AlreadyClaimed()
+func (gasTank *GasTank) UnpackAlreadyClaimedError(raw []byte) (*GasTankAlreadyClaimed, error) {
+	out := new(GasTankAlreadyClaimed)
+	if err := gasTank.abi.UnpackIntoInterface(out, "AlreadyClaimed", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GasTankInsufficientBalance represents a InsufficientBalance error raised by the GasTank contract.
+type GasTankInsufficientBalance struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error InsufficientBalance()
+func GasTankInsufficientBalanceErrorID() common.Hash {
+	return common.HexToHash("0xf4d678b8ce6b5157126b1484a53523762a93571537a7d5ae97d8014a44715c94")
+}
+
+// UnpackInsufficientBalanceError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error InsufficientBalance()
+func (gasTank *GasTank) UnpackInsufficientBalanceError(raw []byte) (*GasTankInsufficientBalance, error) {
+	out := new(GasTankInsufficientBalance)
+	if err := gasTank.abi.UnpackIntoInterface(out, "InsufficientBalance", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GasTankInvalidLength represents a InvalidLength error raised by the GasTank contract.
+type GasTankInvalidLength struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error InvalidLength()
+func GasTankInvalidLengthErrorID() common.Hash {
+	return common.HexToHash("0x947d5a8466b7bcfb56468ee05345bfacfb6c28f016a57c192dadee0d8affb197")
+}
+
+// UnpackInvalidLengthError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error InvalidLength()
+func (gasTank *GasTank) UnpackInvalidLengthError(raw []byte) (*GasTankInvalidLength, error) {
+	out := new(GasTankInvalidLength)
+	if err := gasTank.abi.UnpackIntoInterface(out, "InvalidLength", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GasTankInvalidOrigin represents a InvalidOrigin error raised by the GasTank contract.
+type GasTankInvalidOrigin struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error InvalidOrigin()
+func GasTankInvalidOriginErrorID() common.Hash {
+	return common.HexToHash("0x0a1824e30147953f341a4639e9ffc451c97e896f75e5c49fa4f95aa71cd18a48")
+}
+
+// UnpackInvalidOriginError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error InvalidOrigin()
+func (gasTank *GasTank) UnpackInvalidOriginError(raw []byte) (*GasTankInvalidOrigin, error) {
+	out := new(GasTankInvalidOrigin)
+	if err := gasTank.abi.UnpackIntoInterface(out, "InvalidOrigin", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GasTankInvalidPayload represents a InvalidPayload error raised by the GasTank contract.
+type GasTankInvalidPayload struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error InvalidPayload()
+func GasTankInvalidPayloadErrorID() common.Hash {
+	return common.HexToHash("0x7c6953f9f55fcfd713fe1d72e370e4757e17ae7187e1acdcc02b4b76b56a9a35")
+}
+
+// UnpackInvalidPayloadError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error InvalidPayload()
+func (gasTank *GasTank) UnpackInvalidPayloadError(raw []byte) (*GasTankInvalidPayload, error) {
+	out := new(GasTankInvalidPayload)
+	if err := gasTank.abi.UnpackIntoInterface(out, "InvalidPayload", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GasTankMaxDepositExceeded represents a MaxDepositExceeded error raised by the GasTank contract.
+type GasTankMaxDepositExceeded struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error MaxDepositExceeded()
+func GasTankMaxDepositExceededErrorID() common.Hash {
+	return common.HexToHash("0xdef6daf23e44e297b7f212deded73b848ec32dc31bd1719130a68f1f0a9ea631")
+}
+
+// UnpackMaxDepositExceededError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error MaxDepositExceeded()
+func (gasTank *GasTank) UnpackMaxDepositExceededError(raw []byte) (*GasTankMaxDepositExceeded, error) {
+	out := new(GasTankMaxDepositExceeded)
+	if err := gasTank.abi.UnpackIntoInterface(out, "MaxDepositExceeded", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GasTankMessageNotAuthorized represents a MessageNotAuthorized error raised by the GasTank contract.
+type GasTankMessageNotAuthorized struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error MessageNotAuthorized()
+func GasTankMessageNotAuthorizedErrorID() common.Hash {
+	return common.HexToHash("0xca95853e228a91815913c1af5d29fb78b069cbc9aaa11134533eda83d7809f5b")
+}
+
+// UnpackMessageNotAuthorizedError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error MessageNotAuthorized()
+func (gasTank *GasTank) UnpackMessageNotAuthorizedError(raw []byte) (*GasTankMessageNotAuthorized, error) {
+	out := new(GasTankMessageNotAuthorized)
+	if err := gasTank.abi.UnpackIntoInterface(out, "MessageNotAuthorized", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GasTankWithdrawPending represents a WithdrawPending error raised by the GasTank contract.
+type GasTankWithdrawPending struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error WithdrawPending()
+func GasTankWithdrawPendingErrorID() common.Hash {
+	return common.HexToHash("0xceb0aaa5150bbc505e06fec7ff4514147f5cbc62eabe15dbb62b2819ecfd3573")
+}
+
+// UnpackWithdrawPendingError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error WithdrawPending()
+func (gasTank *GasTank) UnpackWithdrawPendingError(raw []byte) (*GasTankWithdrawPending, error) {
+	out := new(GasTankWithdrawPending)
+	if err := gasTank.abi.UnpackIntoInterface(out, "WithdrawPending", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// L2ToL2CrossDomainMessengerMetaData contains all meta data concerning the L2ToL2CrossDomainMessenger contract.
+var L2ToL2CrossDomainMessengerMetaData = bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"crossDomainMessageContext\",\"inputs\":[],\"outputs\":[{\"name\":\"sender_\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"source_\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"crossDomainMessageSender\",\"inputs\":[],\"outputs\":[{\"name\":\"sender_\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"crossDomainMessageSource\",\"inputs\":[],\"outputs\":[{\"name\":\"source_\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"messageNonce\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"messageVersion\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint16\",\"internalType\":\"uint16\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"relayMessage\",\"inputs\":[{\"name\":\"_id\",\"type\":\"tuple\",\"internalType\":\"structIdentifier\",\"components\":[{\"name\":\"origin\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"blockNumber\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"logIndex\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"timestamp\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"chainId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"name\":\"_sentMessage\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"returnData_\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"resendMessage\",\"inputs\":[{\"name\":\"_destination\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_nonce\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_target\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_message\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"messageHash_\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"sendMessage\",\"inputs\":[{\"name\":\"_destination\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_target\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_message\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"messageHash_\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"sentMessages\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"successfulMessages\",\"inputs\":[{\"name\":\"\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"version\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"string\",\"internalType\":\"string\"}],\"stateMutability\":\"view\"},{\"type\":\"event\",\"name\":\"RelayedMessage\",\"inputs\":[{\"name\":\"source\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"messageNonce\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"messageHash\",\"type\":\"bytes32\",\"indexed\":true,\"internalType\":\"bytes32\"},{\"name\":\"returnDataHash\",\"type\":\"bytes32\",\"indexed\":false,\"internalType\":\"bytes32\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"SentMessage\",\"inputs\":[{\"name\":\"destination\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"target\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"messageNonce\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"sender\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"},{\"name\":\"message\",\"type\":\"bytes\",\"indexed\":false,\"internalType\":\"bytes\"}],\"anonymous\":false},{\"type\":\"error\",\"name\":\"EventPayloadNotSentMessage\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"IdOriginNotL2ToL2CrossDomainMessenger\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidMessage\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"MessageAlreadyRelayed\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"MessageDestinationNotRelayChain\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"MessageDestinationSameChain\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"MessageTargetL2ToL2CrossDomainMessenger\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"NotEntered\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"ReentrantCall\",\"inputs\":[]}]",
+	ID:  "L2ToL2CrossDomainMessenger",
+}
+
+// L2ToL2CrossDomainMessenger is an auto generated Go binding around an Ethereum contract.
+type L2ToL2CrossDomainMessenger struct {
+	abi abi.ABI
+}
+
+// NewL2ToL2CrossDomainMessenger creates a new instance of L2ToL2CrossDomainMessenger.
+func NewL2ToL2CrossDomainMessenger() *L2ToL2CrossDomainMessenger {
+	parsed, err := L2ToL2CrossDomainMessengerMetaData.ParseABI()
+	if err != nil {
+		panic(errors.New("invalid ABI: " + err.Error()))
+	}
+	return &L2ToL2CrossDomainMessenger{abi: *parsed}
+}
+
+// Instance creates a wrapper for a deployed contract instance at the given address.
+// Use this to create the instance object passed to abigen v2 library functions Call, Transact, etc.
+func (c *L2ToL2CrossDomainMessenger) Instance(backend bind.ContractBackend, addr common.Address) *bind.BoundContract {
+	return bind.NewBoundContract(addr, c.abi, backend, backend, backend)
+}
+
+// PackCrossDomainMessageContext is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x7936cbee.
+//
+// Solidity: function crossDomainMessageContext() view returns(address sender_, uint256 source_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) PackCrossDomainMessageContext() []byte {
+	enc, err := l2ToL2CrossDomainMessenger.abi.Pack("crossDomainMessageContext")
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// CrossDomainMessageContextOutput serves as a container for the return parameters of contract
+// method CrossDomainMessageContext.
+type CrossDomainMessageContextOutput struct {
+	Sender common.Address
+	Source *big.Int
+}
+
+// UnpackCrossDomainMessageContext is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x7936cbee.
+//
+// Solidity: function crossDomainMessageContext() view returns(address sender_, uint256 source_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackCrossDomainMessageContext(data []byte) (CrossDomainMessageContextOutput, error) {
+	out, err := l2ToL2CrossDomainMessenger.abi.Unpack("crossDomainMessageContext", data)
+	outstruct := new(CrossDomainMessageContextOutput)
+	if err != nil {
+		return *outstruct, err
+	}
+	outstruct.Sender = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Source = abi.ConvertType(out[1], new(big.Int)).(*big.Int)
+	return *outstruct, err
+
+}
+
+// PackCrossDomainMessageSender is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x38ffde18.
+//
+// Solidity: function crossDomainMessageSender() view returns(address sender_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) PackCrossDomainMessageSender() []byte {
+	enc, err := l2ToL2CrossDomainMessenger.abi.Pack("crossDomainMessageSender")
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackCrossDomainMessageSender is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x38ffde18.
+//
+// Solidity: function crossDomainMessageSender() view returns(address sender_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackCrossDomainMessageSender(data []byte) (common.Address, error) {
+	out, err := l2ToL2CrossDomainMessenger.abi.Unpack("crossDomainMessageSender", data)
+	if err != nil {
+		return *new(common.Address), err
+	}
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	return out0, err
+}
+
+// PackCrossDomainMessageSource is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x24794462.
+//
+// Solidity: function crossDomainMessageSource() view returns(uint256 source_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) PackCrossDomainMessageSource() []byte {
+	enc, err := l2ToL2CrossDomainMessenger.abi.Pack("crossDomainMessageSource")
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackCrossDomainMessageSource is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x24794462.
+//
+// Solidity: function crossDomainMessageSource() view returns(uint256 source_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackCrossDomainMessageSource(data []byte) (*big.Int, error) {
+	out, err := l2ToL2CrossDomainMessenger.abi.Unpack("crossDomainMessageSource", data)
+	if err != nil {
+		return new(big.Int), err
+	}
+	out0 := abi.ConvertType(out[0], new(big.Int)).(*big.Int)
+	return out0, err
+}
+
+// PackMessageNonce is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0xecc70428.
+//
+// Solidity: function messageNonce() view returns(uint256)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) PackMessageNonce() []byte {
+	enc, err := l2ToL2CrossDomainMessenger.abi.Pack("messageNonce")
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackMessageNonce is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0xecc70428.
+//
+// Solidity: function messageNonce() view returns(uint256)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackMessageNonce(data []byte) (*big.Int, error) {
+	out, err := l2ToL2CrossDomainMessenger.abi.Unpack("messageNonce", data)
+	if err != nil {
+		return new(big.Int), err
+	}
+	out0 := abi.ConvertType(out[0], new(big.Int)).(*big.Int)
+	return out0, err
+}
+
+// PackMessageVersion is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x52617f3c.
+//
+// Solidity: function messageVersion() view returns(uint16)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) PackMessageVersion() []byte {
+	enc, err := l2ToL2CrossDomainMessenger.abi.Pack("messageVersion")
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackMessageVersion is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x52617f3c.
+//
+// Solidity: function messageVersion() view returns(uint16)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackMessageVersion(data []byte) (uint16, error) {
+	out, err := l2ToL2CrossDomainMessenger.abi.Unpack("messageVersion", data)
+	if err != nil {
+		return *new(uint16), err
+	}
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+	return out0, err
+}
+
+// PackRelayMessage is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x8d1d298f.
+//
+// Solidity: function relayMessage((address,uint256,uint256,uint256,uint256) _id, bytes _sentMessage) payable returns(bytes returnData_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) PackRelayMessage(id Identifier, sentMessage []byte) []byte {
+	enc, err := l2ToL2CrossDomainMessenger.abi.Pack("relayMessage", id, sentMessage)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackRelayMessage is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x8d1d298f.
+//
+// Solidity: function relayMessage((address,uint256,uint256,uint256,uint256) _id, bytes _sentMessage) payable returns(bytes returnData_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackRelayMessage(data []byte) ([]byte, error) {
+	out, err := l2ToL2CrossDomainMessenger.abi.Unpack("relayMessage", data)
+	if err != nil {
+		return *new([]byte), err
+	}
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+	return out0, err
+}
+
+// PackResendMessage is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x6b0c3c5e.
+//
+// Solidity: function resendMessage(uint256 _destination, uint256 _nonce, address _sender, address _target, bytes _message) returns(bytes32 messageHash_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) PackResendMessage(destination *big.Int, nonce *big.Int, sender common.Address, target common.Address, message []byte) []byte {
+	enc, err := l2ToL2CrossDomainMessenger.abi.Pack("resendMessage", destination, nonce, sender, target, message)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackResendMessage is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x6b0c3c5e.
+//
+// Solidity: function resendMessage(uint256 _destination, uint256 _nonce, address _sender, address _target, bytes _message) returns(bytes32 messageHash_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackResendMessage(data []byte) ([32]byte, error) {
+	out, err := l2ToL2CrossDomainMessenger.abi.Unpack("resendMessage", data)
+	if err != nil {
+		return *new([32]byte), err
+	}
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+	return out0, err
+}
+
+// PackSendMessage is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x7056f41f.
+//
+// Solidity: function sendMessage(uint256 _destination, address _target, bytes _message) returns(bytes32 messageHash_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) PackSendMessage(destination *big.Int, target common.Address, message []byte) []byte {
+	enc, err := l2ToL2CrossDomainMessenger.abi.Pack("sendMessage", destination, target, message)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackSendMessage is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x7056f41f.
+//
+// Solidity: function sendMessage(uint256 _destination, address _target, bytes _message) returns(bytes32 messageHash_)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackSendMessage(data []byte) ([32]byte, error) {
+	out, err := l2ToL2CrossDomainMessenger.abi.Unpack("sendMessage", data)
+	if err != nil {
+		return *new([32]byte), err
+	}
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+	return out0, err
+}
+
+// PackSentMessages is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0xbc294d7d.
+//
+// Solidity: function sentMessages(uint256 ) view returns(bytes32)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) PackSentMessages(arg0 *big.Int) []byte {
+	enc, err := l2ToL2CrossDomainMessenger.abi.Pack("sentMessages", arg0)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackSentMessages is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0xbc294d7d.
+//
+// Solidity: function sentMessages(uint256 ) view returns(bytes32)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackSentMessages(data []byte) ([32]byte, error) {
+	out, err := l2ToL2CrossDomainMessenger.abi.Unpack("sentMessages", data)
+	if err != nil {
+		return *new([32]byte), err
+	}
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+	return out0, err
+}
+
+// PackSuccessfulMessages is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0xb1b1b209.
+//
+// Solidity: function successfulMessages(bytes32 ) view returns(bool)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) PackSuccessfulMessages(arg0 [32]byte) []byte {
+	enc, err := l2ToL2CrossDomainMessenger.abi.Pack("successfulMessages", arg0)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackSuccessfulMessages is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0xb1b1b209.
+//
+// Solidity: function successfulMessages(bytes32 ) view returns(bool)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackSuccessfulMessages(data []byte) (bool, error) {
+	out, err := l2ToL2CrossDomainMessenger.abi.Unpack("successfulMessages", data)
+	if err != nil {
+		return *new(bool), err
+	}
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+	return out0, err
+}
+
+// PackVersion is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x54fd4d50.
+//
+// Solidity: function version() view returns(string)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) PackVersion() []byte {
+	enc, err := l2ToL2CrossDomainMessenger.abi.Pack("version")
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// UnpackVersion is the Go binding that unpacks the parameters returned
+// from invoking the contract method with ID 0x54fd4d50.
+//
+// Solidity: function version() view returns(string)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackVersion(data []byte) (string, error) {
+	out, err := l2ToL2CrossDomainMessenger.abi.Unpack("version", data)
+	if err != nil {
+		return *new(string), err
+	}
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+	return out0, err
+}
+
+// L2ToL2CrossDomainMessengerRelayedMessage represents a RelayedMessage event raised by the L2ToL2CrossDomainMessenger contract.
+type L2ToL2CrossDomainMessengerRelayedMessage struct {
+	Source         *big.Int
+	MessageNonce   *big.Int
+	MessageHash    [32]byte
+	ReturnDataHash [32]byte
+	Raw            *types.Log // Blockchain specific contextual infos
+}
+
+const L2ToL2CrossDomainMessengerRelayedMessageEventName = "RelayedMessage"
+
+// ContractEventName returns the user-defined event name.
+func (L2ToL2CrossDomainMessengerRelayedMessage) ContractEventName() string {
+	return L2ToL2CrossDomainMessengerRelayedMessageEventName
+}
+
+// UnpackRelayedMessageEvent is the Go binding that unpacks the event data emitted
+// by contract.
+//
+// Solidity: event RelayedMessage(uint256 indexed source, uint256 indexed messageNonce, bytes32 indexed messageHash, bytes32 returnDataHash)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackRelayedMessageEvent(log *types.Log) (*L2ToL2CrossDomainMessengerRelayedMessage, error) {
+	event := "RelayedMessage"
+	if log.Topics[0] != l2ToL2CrossDomainMessenger.abi.Events[event].ID {
+		return nil, errors.New("event signature mismatch")
+	}
+	out := new(L2ToL2CrossDomainMessengerRelayedMessage)
+	if len(log.Data) > 0 {
+		if err := l2ToL2CrossDomainMessenger.abi.UnpackIntoInterface(out, event, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range l2ToL2CrossDomainMessenger.abi.Events[event].Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopics(out, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	out.Raw = log
+	return out, nil
+}
+
+// L2ToL2CrossDomainMessengerSentMessage represents a SentMessage event raised by the L2ToL2CrossDomainMessenger contract.
+type L2ToL2CrossDomainMessengerSentMessage struct {
+	Destination  *big.Int
+	Target       common.Address
+	MessageNonce *big.Int
+	Sender       common.Address
+	Message      []byte
+	Raw          *types.Log // Blockchain specific contextual infos
+}
+
+const L2ToL2CrossDomainMessengerSentMessageEventName = "SentMessage"
+
+// ContractEventName returns the user-defined event name.
+func (L2ToL2CrossDomainMessengerSentMessage) ContractEventName() string {
+	return L2ToL2CrossDomainMessengerSentMessageEventName
+}
+
+// UnpackSentMessageEvent is the Go binding that unpacks the event data emitted
+// by contract.
+//
+// Solidity: event SentMessage(uint256 indexed destination, address indexed target, uint256 indexed messageNonce, address sender, bytes message)
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackSentMessageEvent(log *types.Log) (*L2ToL2CrossDomainMessengerSentMessage, error) {
+	event := "SentMessage"
+	if log.Topics[0] != l2ToL2CrossDomainMessenger.abi.Events[event].ID {
+		return nil, errors.New("event signature mismatch")
+	}
+	out := new(L2ToL2CrossDomainMessengerSentMessage)
+	if len(log.Data) > 0 {
+		if err := l2ToL2CrossDomainMessenger.abi.UnpackIntoInterface(out, event, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range l2ToL2CrossDomainMessenger.abi.Events[event].Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopics(out, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	out.Raw = log
+	return out, nil
+}
+
+// UnpackError attempts to decode the provided error data using user-defined
+// error definitions.
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackError(raw []byte) (any, error) {
+	if bytes.Equal(raw[:4], l2ToL2CrossDomainMessenger.abi.Errors["EventPayloadNotSentMessage"].ID.Bytes()[:4]) {
+		return l2ToL2CrossDomainMessenger.UnpackEventPayloadNotSentMessageError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], l2ToL2CrossDomainMessenger.abi.Errors["IdOriginNotL2ToL2CrossDomainMessenger"].ID.Bytes()[:4]) {
+		return l2ToL2CrossDomainMessenger.UnpackIdOriginNotL2ToL2CrossDomainMessengerError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], l2ToL2CrossDomainMessenger.abi.Errors["InvalidMessage"].ID.Bytes()[:4]) {
+		return l2ToL2CrossDomainMessenger.UnpackInvalidMessageError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], l2ToL2CrossDomainMessenger.abi.Errors["MessageAlreadyRelayed"].ID.Bytes()[:4]) {
+		return l2ToL2CrossDomainMessenger.UnpackMessageAlreadyRelayedError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], l2ToL2CrossDomainMessenger.abi.Errors["MessageDestinationNotRelayChain"].ID.Bytes()[:4]) {
+		return l2ToL2CrossDomainMessenger.UnpackMessageDestinationNotRelayChainError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], l2ToL2CrossDomainMessenger.abi.Errors["MessageDestinationSameChain"].ID.Bytes()[:4]) {
+		return l2ToL2CrossDomainMessenger.UnpackMessageDestinationSameChainError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], l2ToL2CrossDomainMessenger.abi.Errors["MessageTargetL2ToL2CrossDomainMessenger"].ID.Bytes()[:4]) {
+		return l2ToL2CrossDomainMessenger.UnpackMessageTargetL2ToL2CrossDomainMessengerError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], l2ToL2CrossDomainMessenger.abi.Errors["NotEntered"].ID.Bytes()[:4]) {
+		return l2ToL2CrossDomainMessenger.UnpackNotEnteredError(raw[4:])
+	}
+	if bytes.Equal(raw[:4], l2ToL2CrossDomainMessenger.abi.Errors["ReentrantCall"].ID.Bytes()[:4]) {
+		return l2ToL2CrossDomainMessenger.UnpackReentrantCallError(raw[4:])
+	}
+	return nil, errors.New("Unknown error")
+}
+
+// L2ToL2CrossDomainMessengerEventPayloadNotSentMessage represents a EventPayloadNotSentMessage error raised by the L2ToL2CrossDomainMessenger contract.
+type L2ToL2CrossDomainMessengerEventPayloadNotSentMessage struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error EventPayloadNotSentMessage()
+func L2ToL2CrossDomainMessengerEventPayloadNotSentMessageErrorID() common.Hash {
+	return common.HexToHash("0xdf1eb58630332391853c38307e06564ea0bbc39dfe0c1ba9c2efe4564c1b0f5c")
+}
+
+// UnpackEventPayloadNotSentMessageError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error EventPayloadNotSentMessage()
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackEventPayloadNotSentMessageError(raw []byte) (*L2ToL2CrossDomainMessengerEventPayloadNotSentMessage, error) {
+	out := new(L2ToL2CrossDomainMessengerEventPayloadNotSentMessage)
+	if err := l2ToL2CrossDomainMessenger.abi.UnpackIntoInterface(out, "EventPayloadNotSentMessage", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// L2ToL2CrossDomainMessengerIdOriginNotL2ToL2CrossDomainMessenger represents a IdOriginNotL2ToL2CrossDomainMessenger error raised by the L2ToL2CrossDomainMessenger contract.
+type L2ToL2CrossDomainMessengerIdOriginNotL2ToL2CrossDomainMessenger struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error IdOriginNotL2ToL2CrossDomainMessenger()
+func L2ToL2CrossDomainMessengerIdOriginNotL2ToL2CrossDomainMessengerErrorID() common.Hash {
+	return common.HexToHash("0x7987c1574049f941a3b7606a784b62305c8a680f5f9ba1bce8d52cdd6e41bfbb")
+}
+
+// UnpackIdOriginNotL2ToL2CrossDomainMessengerError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error IdOriginNotL2ToL2CrossDomainMessenger()
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackIdOriginNotL2ToL2CrossDomainMessengerError(raw []byte) (*L2ToL2CrossDomainMessengerIdOriginNotL2ToL2CrossDomainMessenger, error) {
+	out := new(L2ToL2CrossDomainMessengerIdOriginNotL2ToL2CrossDomainMessenger)
+	if err := l2ToL2CrossDomainMessenger.abi.UnpackIntoInterface(out, "IdOriginNotL2ToL2CrossDomainMessenger", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// L2ToL2CrossDomainMessengerInvalidMessage represents a InvalidMessage error raised by the L2ToL2CrossDomainMessenger contract.
+type L2ToL2CrossDomainMessengerInvalidMessage struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error InvalidMessage()
+func L2ToL2CrossDomainMessengerInvalidMessageErrorID() common.Hash {
+	return common.HexToHash("0x6eca2e4b9e9623a80e50762ec1d27f5418413728059b2f0ef567984d891d6555")
+}
+
+// UnpackInvalidMessageError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error InvalidMessage()
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackInvalidMessageError(raw []byte) (*L2ToL2CrossDomainMessengerInvalidMessage, error) {
+	out := new(L2ToL2CrossDomainMessengerInvalidMessage)
+	if err := l2ToL2CrossDomainMessenger.abi.UnpackIntoInterface(out, "InvalidMessage", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// L2ToL2CrossDomainMessengerMessageAlreadyRelayed represents a MessageAlreadyRelayed error raised by the L2ToL2CrossDomainMessenger contract.
+type L2ToL2CrossDomainMessengerMessageAlreadyRelayed struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error MessageAlreadyRelayed()
+func L2ToL2CrossDomainMessengerMessageAlreadyRelayedErrorID() common.Hash {
+	return common.HexToHash("0x9ca9480bc194e947bb2b227146e18e75aeac4e42b79f4a998922a339e488265c")
+}
+
+// UnpackMessageAlreadyRelayedError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error MessageAlreadyRelayed()
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackMessageAlreadyRelayedError(raw []byte) (*L2ToL2CrossDomainMessengerMessageAlreadyRelayed, error) {
+	out := new(L2ToL2CrossDomainMessengerMessageAlreadyRelayed)
+	if err := l2ToL2CrossDomainMessenger.abi.UnpackIntoInterface(out, "MessageAlreadyRelayed", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// L2ToL2CrossDomainMessengerMessageDestinationNotRelayChain represents a MessageDestinationNotRelayChain error raised by the L2ToL2CrossDomainMessenger contract.
+type L2ToL2CrossDomainMessengerMessageDestinationNotRelayChain struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error MessageDestinationNotRelayChain()
+func L2ToL2CrossDomainMessengerMessageDestinationNotRelayChainErrorID() common.Hash {
+	return common.HexToHash("0x31ac221180d1fb4806a133a716c43a1e19d3e0d825aebe5665ca0a6daffcea2e")
+}
+
+// UnpackMessageDestinationNotRelayChainError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error MessageDestinationNotRelayChain()
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackMessageDestinationNotRelayChainError(raw []byte) (*L2ToL2CrossDomainMessengerMessageDestinationNotRelayChain, error) {
+	out := new(L2ToL2CrossDomainMessengerMessageDestinationNotRelayChain)
+	if err := l2ToL2CrossDomainMessenger.abi.UnpackIntoInterface(out, "MessageDestinationNotRelayChain", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// L2ToL2CrossDomainMessengerMessageDestinationSameChain represents a MessageDestinationSameChain error raised by the L2ToL2CrossDomainMessenger contract.
+type L2ToL2CrossDomainMessengerMessageDestinationSameChain struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error MessageDestinationSameChain()
+func L2ToL2CrossDomainMessengerMessageDestinationSameChainErrorID() common.Hash {
+	return common.HexToHash("0x8ed9a95dc5eb01a066bbb1b7b71ce1fca4be3ab72bea2e6578052d918cf0c8e7")
+}
+
+// UnpackMessageDestinationSameChainError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error MessageDestinationSameChain()
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackMessageDestinationSameChainError(raw []byte) (*L2ToL2CrossDomainMessengerMessageDestinationSameChain, error) {
+	out := new(L2ToL2CrossDomainMessengerMessageDestinationSameChain)
+	if err := l2ToL2CrossDomainMessenger.abi.UnpackIntoInterface(out, "MessageDestinationSameChain", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// L2ToL2CrossDomainMessengerMessageTargetL2ToL2CrossDomainMessenger represents a MessageTargetL2ToL2CrossDomainMessenger error raised by the L2ToL2CrossDomainMessenger contract.
+type L2ToL2CrossDomainMessengerMessageTargetL2ToL2CrossDomainMessenger struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error MessageTargetL2ToL2CrossDomainMessenger()
+func L2ToL2CrossDomainMessengerMessageTargetL2ToL2CrossDomainMessengerErrorID() common.Hash {
+	return common.HexToHash("0x4faa2509fe04c3a89913227e6a549f69f102cd9494c3e4a9c1d7b8df6f405757")
+}
+
+// UnpackMessageTargetL2ToL2CrossDomainMessengerError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error MessageTargetL2ToL2CrossDomainMessenger()
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackMessageTargetL2ToL2CrossDomainMessengerError(raw []byte) (*L2ToL2CrossDomainMessengerMessageTargetL2ToL2CrossDomainMessenger, error) {
+	out := new(L2ToL2CrossDomainMessengerMessageTargetL2ToL2CrossDomainMessenger)
+	if err := l2ToL2CrossDomainMessenger.abi.UnpackIntoInterface(out, "MessageTargetL2ToL2CrossDomainMessenger", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// L2ToL2CrossDomainMessengerNotEntered represents a NotEntered error raised by the L2ToL2CrossDomainMessenger contract.
+type L2ToL2CrossDomainMessengerNotEntered struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error NotEntered()
+func L2ToL2CrossDomainMessengerNotEnteredErrorID() common.Hash {
+	return common.HexToHash("0xbca35af6ee33f61c25cc32a63a589649d3ddc668e0deb284281ca51d1fc8b2d2")
+}
+
+// UnpackNotEnteredError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error NotEntered()
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackNotEnteredError(raw []byte) (*L2ToL2CrossDomainMessengerNotEntered, error) {
+	out := new(L2ToL2CrossDomainMessengerNotEntered)
+	if err := l2ToL2CrossDomainMessenger.abi.UnpackIntoInterface(out, "NotEntered", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// L2ToL2CrossDomainMessengerReentrantCall represents a ReentrantCall error raised by the L2ToL2CrossDomainMessenger contract.
+type L2ToL2CrossDomainMessengerReentrantCall struct {
+}
+
+// ErrorID returns the hash of canonical representation of the error's signature.
+//
+// Solidity: error ReentrantCall()
+func L2ToL2CrossDomainMessengerReentrantCallErrorID() common.Hash {
+	return common.HexToHash("0x37ed32e8b98f05c268547554617c26adb4478b89452c6df1b1c862cb749b1c27")
+}
+
+// UnpackReentrantCallError is the Go binding used to decode the provided
+// error data into the corresponding Go error struct.
+//
+// Solidity: error ReentrantCall()
+func (l2ToL2CrossDomainMessenger *L2ToL2CrossDomainMessenger) UnpackReentrantCallError(raw []byte) (*L2ToL2CrossDomainMessengerReentrantCall, error) {
+	out := new(L2ToL2CrossDomainMessengerReentrantCall)
+	if err := l2ToL2CrossDomainMessenger.abi.UnpackIntoInterface(out, "ReentrantCall", raw); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MessageSenderMetaData contains all meta data concerning the MessageSender contract.
+var MessageSenderMetaData = bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"sendMessages\",\"inputs\":[{\"name\":\"_destinationChainId\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_numMessages\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"}]",
+	ID:  "MessageSender",
+}
+
+// MessageSender is an auto generated Go binding around an Ethereum contract.
+type MessageSender struct {
+	abi abi.ABI
+}
+
+// NewMessageSender creates a new instance of MessageSender.
+func NewMessageSender() *MessageSender {
+	parsed, err := MessageSenderMetaData.ParseABI()
+	if err != nil {
+		panic(errors.New("invalid ABI: " + err.Error()))
+	}
+	return &MessageSender{abi: *parsed}
+}
+
+// Instance creates a wrapper for a deployed contract instance at the given address.
+// Use this to create the instance object passed to abigen v2 library functions Call, Transact, etc.
+func (c *MessageSender) Instance(backend bind.ContractBackend, addr common.Address) *bind.BoundContract {
+	return bind.NewBoundContract(addr, c.abi, backend, backend, backend)
+}
+
+// PackSendMessages is the Go binding used to pack the parameters required for calling
+// the contract method with ID 0x541f04e6.
+//
+// Solidity: function sendMessages(uint256 _destinationChainId, uint256 _numMessages) returns()
+func (messageSender *MessageSender) PackSendMessages(destinationChainId *big.Int, numMessages *big.Int) []byte {
+	enc, err := messageSender.abi.Pack("sendMessages", destinationChainId, numMessages)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
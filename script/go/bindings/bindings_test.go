@@ -0,0 +1,144 @@
+package bindings
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"supersim-e2e-example/bindings/internal/bindgen"
+)
+
+// foundryOut is the Foundry out/ directory relative to this package
+const foundryOut = "../../../out"
+
+// gasTankInterface is the Solidity interface the GasTank snapshot is checked against without forge
+const gasTankInterface = "../../../interfaces/IGasTank.sol"
+
+// TestBindingsUpToDate fails when the generated files were not regenerated after an ABI snapshot changed
+func TestBindingsUpToDate(t *testing.T) {
+	files, err := bindgen.Generate("abi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date, run go generate ./bindings", name)
+		}
+	}
+}
+
+// TestSnapshotsMatchArtifacts fails when a contract changed without its ABI snapshot being refreshed.
+// It is skipped for the contracts that have not been built with forge build.
+func TestSnapshotsMatchArtifacts(t *testing.T) {
+	for _, contract := range bindgen.Contracts {
+		t.Run(contract.Name, func(t *testing.T) {
+			artifact, err := bindgen.ArtifactABI(foundryOut, contract)
+			if errors.Is(err, bindgen.ErrNoArtifact) {
+				t.Skip(err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			snapshot, err := os.ReadFile(bindgen.SnapshotPath("abi", contract))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameABI(t, artifact, snapshot) {
+				t.Fatalf("abi/%s.json differs from the Foundry artifact, run go generate ./bindings", contract.Name)
+			}
+		})
+	}
+}
+
+// TestGasTankMatchesInterface checks every function, event and error signature declared in IGasTank.sol
+// against the GasTank ABI snapshot, so that drift is caught even when the contracts were not built
+func TestGasTankMatchesInterface(t *testing.T) {
+	source, err := os.ReadFile(gasTankInterface)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := GasTankMetaData.ParseABI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	declarations := regexp.MustCompile(`(function|event|error)\s+(\w+)\s*\(([^)]*)\)`).FindAllStringSubmatch(string(source), -1)
+	if len(declarations) == 0 {
+		t.Fatalf("no declarations found in %s", gasTankInterface)
+	}
+	for _, decl := range declarations {
+		kind, name := decl[1], decl[2]
+		want := name + "(" + solidityTypes(decl[3]) + ")"
+		var got string
+		switch kind {
+		case "function":
+			got = parsed.Methods[name].Sig
+		case "event":
+			got = parsed.Events[name].Sig
+		case "error":
+			got = parsed.Errors[name].Sig
+		}
+		if got != want {
+			t.Errorf("%s %s is declared as %s in IGasTank.sol but bound as %q", kind, name, want, got)
+		}
+	}
+}
+
+// solidityTypes turns a Solidity parameter list into the canonical type list of an ABI signature
+func solidityTypes(params string) string {
+	// Types the interface uses that are not elementary ABI types
+	aliases := map[string]string{
+		"Identifier":                  "(address,uint256,uint256,uint256,uint256)",
+		"IL2ToL2CrossDomainMessenger": "address",
+	}
+	var types []string
+	for _, param := range strings.Split(params, ",") {
+		fields := strings.Fields(param)
+		if len(fields) == 0 {
+			continue
+		}
+		typ := fields[0]
+		if alias, ok := aliases[typ]; ok {
+			typ = alias
+		}
+		types = append(types, typ)
+	}
+	return strings.Join(types, ",")
+}
+
+// sameABI compares two ABIs as sets of entries, ignoring formatting and ordering
+func sameABI(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	return reflect.DeepEqual(abiEntries(t, a), abiEntries(t, b))
+}
+
+func abiEntries(t *testing.T, data []byte) map[string]bool {
+	t.Helper()
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatal(err)
+	}
+	set := make(map[string]bool)
+	for _, entry := range entries {
+		// Round trip through a map so that key order does not matter
+		var fields map[string]any
+		if err := json.Unmarshal(entry, &fields); err != nil {
+			t.Fatal(err)
+		}
+		canonical, err := json.Marshal(fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		set[string(canonical)] = true
+	}
+	return set
+}
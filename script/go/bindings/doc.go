@@ -0,0 +1,8 @@
+// Package bindings contains the Go bindings of the contracts the scripts interact with: GasTank,
+// MessageSender and the L2ToL2CrossDomainMessenger and CrossL2Inbox predeploys.
+//
+// bindings.go is generated from the ABI snapshots in abi/, which are refreshed from the Foundry
+// artifacts. Run forge build followed by go generate ./bindings after changing a contract.
+package bindings
+
+//go:generate go run ./gen
@@ -0,0 +1,114 @@
+// Code generated by bindgen - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+// Error makes the BlockNumberTooHigh error of the CrossL2Inbox contract a Go error.
+func (e *CrossL2InboxBlockNumberTooHigh) Error() string {
+	return "CrossL2Inbox: BlockNumberTooHigh()"
+}
+
+// Error makes the LogIndexTooHigh error of the CrossL2Inbox contract a Go error.
+func (e *CrossL2InboxLogIndexTooHigh) Error() string {
+	return "CrossL2Inbox: LogIndexTooHigh()"
+}
+
+// Error makes the NoExecutingDeposits error of the CrossL2Inbox contract a Go error.
+func (e *CrossL2InboxNoExecutingDeposits) Error() string {
+	return "CrossL2Inbox: NoExecutingDeposits()"
+}
+
+// Error makes the NotInAccessList error of the CrossL2Inbox contract a Go error.
+func (e *CrossL2InboxNotInAccessList) Error() string {
+	return "CrossL2Inbox: NotInAccessList()"
+}
+
+// Error makes the TimestampTooHigh error of the CrossL2Inbox contract a Go error.
+func (e *CrossL2InboxTimestampTooHigh) Error() string {
+	return "CrossL2Inbox: TimestampTooHigh()"
+}
+
+// Error makes the AlreadyClaimed error of the GasTank contract a Go error.
+func (e *GasTankAlreadyClaimed) Error() string {
+	return "GasTank: AlreadyClaimed()"
+}
+
+// Error makes the InsufficientBalance error of the GasTank contract a Go error.
+func (e *GasTankInsufficientBalance) Error() string {
+	return "GasTank: InsufficientBalance()"
+}
+
+// Error makes the InvalidLength error of the GasTank contract a Go error.
+func (e *GasTankInvalidLength) Error() string {
+	return "GasTank: InvalidLength()"
+}
+
+// Error makes the InvalidOrigin error of the GasTank contract a Go error.
+func (e *GasTankInvalidOrigin) Error() string {
+	return "GasTank: InvalidOrigin()"
+}
+
+// Error makes the InvalidPayload error of the GasTank contract a Go error.
+func (e *GasTankInvalidPayload) Error() string {
+	return "GasTank: InvalidPayload()"
+}
+
+// Error makes the MaxDepositExceeded error of the GasTank contract a Go error.
+func (e *GasTankMaxDepositExceeded) Error() string {
+	return "GasTank: MaxDepositExceeded()"
+}
+
+// Error makes the MessageNotAuthorized error of the GasTank contract a Go error.
+func (e *GasTankMessageNotAuthorized) Error() string {
+	return "GasTank: MessageNotAuthorized()"
+}
+
+// Error makes the WithdrawPending error of the GasTank contract a Go error.
+func (e *GasTankWithdrawPending) Error() string {
+	return "GasTank: WithdrawPending()"
+}
+
+// Error makes the EventPayloadNotSentMessage error of the L2ToL2CrossDomainMessenger contract a Go error.
+func (e *L2ToL2CrossDomainMessengerEventPayloadNotSentMessage) Error() string {
+	return "L2ToL2CrossDomainMessenger: EventPayloadNotSentMessage()"
+}
+
+// Error makes the IdOriginNotL2ToL2CrossDomainMessenger error of the L2ToL2CrossDomainMessenger contract a Go error.
+func (e *L2ToL2CrossDomainMessengerIdOriginNotL2ToL2CrossDomainMessenger) Error() string {
+	return "L2ToL2CrossDomainMessenger: IdOriginNotL2ToL2CrossDomainMessenger()"
+}
+
+// Error makes the InvalidMessage error of the L2ToL2CrossDomainMessenger contract a Go error.
+func (e *L2ToL2CrossDomainMessengerInvalidMessage) Error() string {
+	return "L2ToL2CrossDomainMessenger: InvalidMessage()"
+}
+
+// Error makes the MessageAlreadyRelayed error of the L2ToL2CrossDomainMessenger contract a Go error.
+func (e *L2ToL2CrossDomainMessengerMessageAlreadyRelayed) Error() string {
+	return "L2ToL2CrossDomainMessenger: MessageAlreadyRelayed()"
+}
+
+// Error makes the MessageDestinationNotRelayChain error of the L2ToL2CrossDomainMessenger contract a Go error.
+func (e *L2ToL2CrossDomainMessengerMessageDestinationNotRelayChain) Error() string {
+	return "L2ToL2CrossDomainMessenger: MessageDestinationNotRelayChain()"
+}
+
+// Error makes the MessageDestinationSameChain error of the L2ToL2CrossDomainMessenger contract a Go error.
+func (e *L2ToL2CrossDomainMessengerMessageDestinationSameChain) Error() string {
+	return "L2ToL2CrossDomainMessenger: MessageDestinationSameChain()"
+}
+
+// Error makes the MessageTargetL2ToL2CrossDomainMessenger error of the L2ToL2CrossDomainMessenger contract a Go error.
+func (e *L2ToL2CrossDomainMessengerMessageTargetL2ToL2CrossDomainMessenger) Error() string {
+	return "L2ToL2CrossDomainMessenger: MessageTargetL2ToL2CrossDomainMessenger()"
+}
+
+// Error makes the NotEntered error of the L2ToL2CrossDomainMessenger contract a Go error.
+func (e *L2ToL2CrossDomainMessengerNotEntered) Error() string {
+	return "L2ToL2CrossDomainMessenger: NotEntered()"
+}
+
+// Error makes the ReentrantCall error of the L2ToL2CrossDomainMessenger contract a Go error.
+func (e *L2ToL2CrossDomainMessengerReentrantCall) Error() string {
+	return "L2ToL2CrossDomainMessenger: ReentrantCall()"
+}
@@ -0,0 +1,45 @@
+// Command gen refreshes the ABI snapshots from the Foundry artifacts and regenerates the bindings.
+// It is run through go generate from the bindings directory.
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"supersim-e2e-example/bindings/internal/bindgen"
+)
+
+func main() {
+	outDir := flag.String("out", "../../../out", "Foundry out/ directory.")
+	abiDir := flag.String("abi", "abi", "Directory holding the ABI snapshots.")
+	output := flag.String("o", ".", "Directory the generated Go files are written to.")
+	flag.Parse()
+
+	for _, contract := range bindgen.Contracts {
+		abi, err := bindgen.ArtifactABI(*outDir, contract)
+		if errors.Is(err, bindgen.ErrNoArtifact) {
+			log.Printf("Keeping the %s ABI snapshot: %v", contract.Name, err)
+			continue
+		}
+		if err != nil {
+			log.Fatalf("Failed to read %s artifact: %v", contract.Name, err)
+		}
+		if err := os.WriteFile(bindgen.SnapshotPath(*abiDir, contract), abi, 0o644); err != nil {
+			log.Fatalf("Failed to write %s ABI snapshot: %v", contract.Name, err)
+		}
+	}
+
+	files, err := bindgen.Generate(*abiDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, code := range files {
+		path := filepath.Join(*output, name)
+		if err := os.WriteFile(path, code, 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Package bindgen generates the Go contract bindings from the ABI snapshots in bindings/abi and refreshes
+// those snapshots from the Foundry out/ artifacts when they are available. Besides the abigen output it
+// generates an Error method for every custom error struct, so that reverts decode to Go errors.
+package bindgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/abigen"
+)
+
+// Package is the name of the generated Go package
+const Package = "bindings"
+
+// Generated files, relative to the bindings package
+const (
+	BindingsFile = "bindings.go"
+	ErrorsFile   = "errors.go"
+)
+
+// Contract is a contract the bindings are generated for
+type Contract struct {
+	// Name is the Go type name and the name of the ABI snapshot in bindings/abi
+	Name string
+	// Artifacts are the Foundry artifacts the ABI can be read from, relative to out/, in order of preference.
+	// Predeploys are only compiled as the interfaces the contracts import.
+	Artifacts []string
+}
+
+// Contracts lists every contract with generated bindings
+var Contracts = []Contract{
+	{Name: "GasTank", Artifacts: []string{"GasTank.sol/GasTank.json"}},
+	{Name: "MessageSender", Artifacts: []string{"MessageSender.sol/MessageSender.json"}},
+	{Name: "L2ToL2CrossDomainMessenger", Artifacts: []string{
+		"L2ToL2CrossDomainMessenger.sol/L2ToL2CrossDomainMessenger.json",
+		"IL2ToL2CrossDomainMessenger.sol/IL2ToL2CrossDomainMessenger.json",
+	}},
+	{Name: "CrossL2Inbox", Artifacts: []string{
+		"CrossL2Inbox.sol/CrossL2Inbox.json",
+		"ICrossL2Inbox.sol/ICrossL2Inbox.json",
+	}},
+}
+
+// ErrNoArtifact is returned when none of a contract's Foundry artifacts exist
+var ErrNoArtifact = errors.New("no Foundry artifact found, run forge build")
+
+// ArtifactABI reads the ABI of a contract from the first of its Foundry artifacts found in outDir
+func ArtifactABI(outDir string, contract Contract) ([]byte, error) {
+	for _, artifact := range contract.Artifacts {
+		data, err := os.ReadFile(filepath.Join(outDir, artifact))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var parsed struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(data, &parsed); err != nil {
+			return nil, fmt.Errorf("failed to parse artifact %s: %w", artifact, err)
+		}
+		if len(parsed.ABI) == 0 {
+			return nil, fmt.Errorf("artifact %s has no abi", artifact)
+		}
+		return FormatABI(parsed.ABI)
+	}
+	return nil, fmt.Errorf("%s: %w", contract.Name, ErrNoArtifact)
+}
+
+// FormatABI indents an ABI the way the snapshots in bindings/abi are stored
+func FormatABI(raw []byte) ([]byte, error) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, raw, "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// SnapshotPath returns the path of a contract's ABI snapshot in abiDir
+func SnapshotPath(abiDir string, contract Contract) string {
+	return filepath.Join(abiDir, contract.Name+".json")
+}
+
+// Generate returns the generated Go files for the ABI snapshots in abiDir, keyed by file name
+func Generate(abiDir string) (map[string][]byte, error) {
+	var types, abis, bytecodes []string
+	for _, contract := range Contracts {
+		data, err := os.ReadFile(SnapshotPath(abiDir, contract))
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, contract.Name)
+		abis = append(abis, string(data))
+		// The bindings are only used against deployed contracts, deployment goes through forge
+		bytecodes = append(bytecodes, "")
+	}
+	code, err := abigen.BindV2(types, abis, bytecodes, Package, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate bindings: %w", err)
+	}
+	errorsCode, err := generateErrors(types, abis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate errors: %w", err)
+	}
+	return map[string][]byte{BindingsFile: []byte(code), ErrorsFile: errorsCode}, nil
+}
+
+// customError is a custom Solidity error bound by abigen as the struct Contract+Name
+type customError struct {
+	Contract string
+	Name     string
+	Fields   []string
+}
+
+// Type is the name of the struct abigen generated for the error
+func (e customError) Type() string {
+	return e.Contract + e.Name
+}
+
+// Format is the fmt format of the error message
+func (e customError) Format() string {
+	verbs := make([]string, len(e.Fields))
+	for i := range verbs {
+		verbs[i] = "%v"
+	}
+	return e.Contract + ": " + e.Name + "(" + strings.Join(verbs, ", ") + ")"
+}
+
+var errorsTemplate = template.Must(template.New("errors").Parse(`// Code generated by bindgen - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+{{if .UsesFmt}}import "fmt"{{end}}
+
+{{range .Errors}}
+// Error makes the {{.Name}} error of the {{.Contract}} contract a Go error.
+func (e *{{.Type}}) Error() string {
+	{{- if .Fields}}
+	return fmt.Sprintf("{{.Format}}"{{range .Fields}}, e.{{.}}{{end}})
+	{{- else}}
+	return "{{.Format}}"
+	{{- end}}
+}
+{{end}}
+`))
+
+// generateErrors returns the source of the Error methods of every custom error declared in abis
+func generateErrors(types, abis []string) ([]byte, error) {
+	var customErrors []customError
+	usesFmt := false
+	for i, contract := range types {
+		parsed, err := abi.JSON(strings.NewReader(abis[i]))
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for name := range parsed.Errors {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			// Mirror the names abigen gives to the error structs and their fields
+			customErr := customError{Contract: contract, Name: abi.ToCamelCase(name)}
+			for j, input := range parsed.Errors[name].Inputs {
+				field := input.Name
+				if field == "" {
+					field = fmt.Sprintf("arg%d", j)
+				}
+				customErr.Fields = append(customErr.Fields, abi.ToCamelCase(field))
+			}
+			usesFmt = usesFmt || len(customErr.Fields) > 0
+			customErrors = append(customErrors, customErr)
+		}
+	}
+	sort.Slice(customErrors, func(i, j int) bool { return customErrors[i].Type() < customErrors[j].Type() })
+
+	var buf bytes.Buffer
+	data := map[string]any{"Package": Package, "Errors": customErrors, "UsesFmt": usesFmt}
+	if err := errorsTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
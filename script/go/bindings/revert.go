@@ -0,0 +1,44 @@
+package bindings
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// revertDecoders decode the custom errors of every bound contract. Errors raised by the predeploys bubble up
+// through the GasTank, so each decoder is tried whatever contract was called.
+var revertDecoders = []func([]byte) (any, error){
+	NewGasTank().UnpackError,
+	NewL2ToL2CrossDomainMessenger().UnpackError,
+	NewCrossL2Inbox().UnpackError,
+}
+
+// RevertReason is a revert raised by require, revert("...") or a Solidity panic
+type RevertReason struct {
+	Reason string
+}
+
+func (e *RevertReason) Error() string {
+	return "execution reverted: " + e.Reason
+}
+
+// DecodeRevert decodes revert data into the typed error of the custom error it encodes, such as
+// *GasTankAlreadyClaimed, or into a *RevertReason. It returns nil when the data matches none of them.
+func DecodeRevert(data []byte) error {
+	if len(data) < 4 {
+		return nil
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return &RevertReason{Reason: reason}
+	}
+	for _, decode := range revertDecoders {
+		decoded, err := decode(data)
+		if err != nil {
+			continue
+		}
+		// Every error struct implements error through the generated errors.go
+		if typed, ok := decoded.(error); ok {
+			return typed
+		}
+	}
+	return nil
+}
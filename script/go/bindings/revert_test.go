@@ -0,0 +1,83 @@
+package bindings
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestDecodeRevert(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+		// as checks that the decoded error matches the expected type with errors.As
+		as func(error) bool
+	}{
+		{
+			name: "GasTank error",
+			data: GasTankAlreadyClaimedErrorID().Bytes()[:4],
+			want: "GasTank: AlreadyClaimed()",
+			as: func(err error) bool {
+				var target *GasTankAlreadyClaimed
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name: "messenger error bubbled up through the GasTank",
+			data: L2ToL2CrossDomainMessengerMessageAlreadyRelayedErrorID().Bytes()[:4],
+			want: "L2ToL2CrossDomainMessenger: MessageAlreadyRelayed()",
+			as: func(err error) bool {
+				var target *L2ToL2CrossDomainMessengerMessageAlreadyRelayed
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name: "CrossL2Inbox error",
+			data: CrossL2InboxNotInAccessListErrorID().Bytes()[:4],
+			want: "CrossL2Inbox: NotInAccessList()",
+			as: func(err error) bool {
+				var target *CrossL2InboxNotInAccessList
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name: "require reason",
+			// Error("nope")
+			data: hexutil.MustDecode("0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"0000000000000000000000000000000000000000000000000000000000000004" +
+				"6e6f706500000000000000000000000000000000000000000000000000000000"),
+			want: "execution reverted: nope",
+			as: func(err error) bool {
+				var target *RevertReason
+				return errors.As(err, &target)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DecodeRevert(tt.data)
+			if err == nil {
+				t.Fatal("revert data was not decoded")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("got %q, want %q", err.Error(), tt.want)
+			}
+			if !tt.as(fmt.Errorf("wrapped: %w", err)) {
+				t.Errorf("errors.As does not match %T", err)
+			}
+		})
+	}
+}
+
+func TestDecodeRevertUnknown(t *testing.T) {
+	for _, data := range [][]byte{nil, {0x01, 0x02}, common.FromHex("0xdeadbeef")} {
+		if err := DecodeRevert(data); err != nil {
+			t.Errorf("DecodeRevert(%x) = %v, want nil", data, err)
+		}
+	}
+}
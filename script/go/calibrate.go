@@ -0,0 +1,382 @@
+// This script calibrates the overhead constants of GasTank._relayOverhead and GasTank.claimOverhead: it sweeps
+// nested message counts, or reads existing results files, and fits a fixed + linear + quadratic model to the
+// overhead that would have made the relay and claim gas deltas zero.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The constants of GasTank._relayOverhead the calibration starts from, keep them in sync with the contract. The
+// function is internal, unlike claimOverhead which is read from the deployed GasTank.
+const (
+	relayOverheadFixed   = 34_205
+	relayOverheadPerHash = 418
+)
+
+// currentRelayOverhead returns the gas GasTank._relayOverhead adds for the given number of nested hashes
+func currentRelayOverhead(numHashes int) float64 {
+	return relayOverheadFixed + relayOverheadPerHash*float64(numHashes)
+}
+
+// readClaimOverheads reads the gas GasTank.claimOverhead adds for every given number of nested hashes, without the
+// L1 data fee: claimOverhead(_numHashes, 1, "") charges the gas at a base fee of 1 wei plus the L1 data fee of the
+// empty _data, which is subtracted. All calls read the same block.
+func readClaimOverheads(ctx context.Context, chain *gasTankChain, counts []int) (map[int]float64, error) {
+	head, err := chain.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the block number of chain %d: %w", chain.ChainID, err)
+	}
+	blockNumber := new(big.Int).SetUint64(head)
+
+	call := func(to common.Address, calldata []byte) ([]byte, error) {
+		return chain.client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: calldata}, blockNumber)
+	}
+	calldata, err := gasPriceOracleABI.Pack("getL1Fee", []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode getL1Fee call: %w", err)
+	}
+	result, err := call(gasPriceOracleAddr, calldata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call getL1Fee: %w", err)
+	}
+	unpacked, err := gasPriceOracleABI.Unpack("getL1Fee", result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode getL1Fee result: %w", err)
+	}
+	l1Fee := unpacked[0].(*big.Int)
+
+	overheads := make(map[int]float64)
+	for _, numHashes := range counts {
+		result, err := call(chain.gasTank, gasTankContract.PackClaimOverhead(big.NewInt(int64(numHashes)), big.NewInt(1), []byte{}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to call claimOverhead(%d): %w", numHashes, err)
+		}
+		overhead, err := gasTankContract.UnpackClaimOverhead(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode claimOverhead(%d): %w", numHashes, err)
+		}
+		overheads[numHashes], _ = new(big.Float).SetInt(new(big.Int).Sub(overhead, l1Fee)).Float64()
+	}
+	return overheads, nil
+}
+
+// overheadPoint is the overhead a sample needed for its gas delta to be zero
+type overheadPoint struct {
+	numHashes int
+	overhead  float64
+}
+
+// overheadFit is a fixed + linear + quadratic model of the needed overhead
+type overheadFit struct {
+	Fixed, Linear, Quadratic float64
+}
+
+func (f overheadFit) at(numHashes int) float64 {
+	n := float64(numHashes)
+	return f.Fixed + f.Linear*n + f.Quadratic*n*n
+}
+
+// neededOverheads converts the samples into the overhead that would have zeroed their gas deltas, given the current
+// claim overhead of every nested message count. The deltas include the L1 data fee as the contract charges it, so
+// the fit zeroes the delta gasanalysis reports.
+func neededOverheads(results []*gasAnalysisResults, currentClaim map[int]float64) (relay, claim []overheadPoint, err error) {
+	for _, result := range results {
+		for _, c := range result.Cases {
+			claimOverhead, ok := currentClaim[c.NestedMessages]
+			if !ok && len(c.Samples) > 0 {
+				return nil, nil, fmt.Errorf("no current claim overhead for %d nested messages", c.NestedMessages)
+			}
+			for _, sample := range c.Samples {
+				relayDelta, _ := new(big.Float).SetInt(sample.Relay.GasDelta).Float64()
+				claimDelta, _ := new(big.Float).SetInt(sample.Claim.GasDelta).Float64()
+				relay = append(relay, overheadPoint{c.NestedMessages, currentRelayOverhead(c.NestedMessages) - relayDelta})
+				claim = append(claim, overheadPoint{c.NestedMessages, claimOverhead - claimDelta})
+			}
+		}
+	}
+	return relay, claim, nil
+}
+
+// sampledNestedCounts returns the distinct nested message counts with samples in increasing order
+func sampledNestedCounts(results []*gasAnalysisResults) []int {
+	var points []overheadPoint
+	for _, result := range results {
+		for _, c := range result.Cases {
+			if len(c.Samples) > 0 {
+				points = append(points, overheadPoint{numHashes: c.NestedMessages})
+			}
+		}
+	}
+	return nestedCounts(points)
+}
+
+// fitOverhead fits the model to the points by least squares, dropping the quadratic and then the linear term
+// when there are not enough distinct nested message counts to determine them
+func fitOverhead(points []overheadPoint) (overheadFit, error) {
+	distinct := make(map[int]bool)
+	for _, p := range points {
+		distinct[p.numHashes] = true
+	}
+	terms := min(len(distinct), 3)
+	if terms == 0 {
+		return overheadFit{}, fmt.Errorf("no samples to fit")
+	}
+
+	// Normal equations (XᵀX)β = Xᵀy with X = [1 n n²]
+	var xtx [3][3]float64
+	var xty [3]float64
+	for _, p := range points {
+		n := float64(p.numHashes)
+		row := [3]float64{1, n, n * n}
+		for i := 0; i < terms; i++ {
+			for j := 0; j < terms; j++ {
+				xtx[i][j] += row[i] * row[j]
+			}
+			xty[i] += row[i] * p.overhead
+		}
+	}
+	beta, err := solveLinearSystem(xtx, xty, terms)
+	if err != nil {
+		return overheadFit{}, err
+	}
+	return overheadFit{Fixed: beta[0], Linear: beta[1], Quadratic: beta[2]}, nil
+}
+
+// solveLinearSystem solves the first size equations of a·x = b by Gaussian elimination with partial pivoting
+func solveLinearSystem(a [3][3]float64, b [3]float64, size int) ([3]float64, error) {
+	for col := 0; col < size; col++ {
+		pivot := col
+		for row := col + 1; row < size; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return [3]float64{}, fmt.Errorf("singular system, the samples do not determine the model")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		for row := col + 1; row < size; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < size; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+
+	var x [3]float64
+	for row := size - 1; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < size; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, nil
+}
+
+// meanOverhead returns the mean overhead needed by the points with the given number of hashes
+func meanOverhead(points []overheadPoint, numHashes int) (float64, bool) {
+	var sum float64
+	var count int
+	for _, p := range points {
+		if p.numHashes == numHashes {
+			sum += p.overhead
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+// calibration holds the fitted overheads. Like claimOverhead, the claim model treats 0 and 1 nested hashes
+// separately and fits the quadratic model to 2 or more hashes only.
+type calibration struct {
+	relay        overheadFit
+	claim        overheadFit
+	claimFit     bool
+	claim0       float64
+	claim0Set    bool
+	claim1       float64
+	claim1Set    bool
+	relayPts     []overheadPoint
+	claimPts     []overheadPoint
+	currentClaim map[int]float64
+}
+
+func calibrate(results []*gasAnalysisResults, currentClaim map[int]float64) (*calibration, error) {
+	relay, claim, err := neededOverheads(results, currentClaim)
+	if err != nil {
+		return nil, err
+	}
+	c := &calibration{relayPts: relay, claimPts: claim, currentClaim: currentClaim}
+
+	if c.relay, err = fitOverhead(relay); err != nil {
+		return nil, fmt.Errorf("failed to fit the relay overhead: %w", err)
+	}
+	c.claim0, c.claim0Set = meanOverhead(claim, 0)
+	c.claim1, c.claim1Set = meanOverhead(claim, 1)
+	var multiHash []overheadPoint
+	for _, p := range claim {
+		if p.numHashes >= 2 {
+			multiHash = append(multiHash, p)
+		}
+	}
+	if len(multiHash) > 0 {
+		if c.claim, err = fitOverhead(multiHash); err != nil {
+			return nil, fmt.Errorf("failed to fit the claim overhead: %w", err)
+		}
+		c.claimFit = true
+	}
+	return c, nil
+}
+
+// claimAt returns the fitted claim overhead for the given number of hashes
+func (c *calibration) claimAt(numHashes int) (float64, bool) {
+	switch {
+	case numHashes == 0:
+		return c.claim0, c.claim0Set
+	case numHashes == 1:
+		return c.claim1, c.claim1Set
+	}
+	return c.claim.at(numHashes), c.claimFit
+}
+
+// write prints the suggested constants and the residual error of the fit per nested message count
+func (c *calibration) write(w io.Writer) error {
+	fmt.Fprintln(w, "Suggested _relayOverhead:")
+	fmt.Fprintf(w, "  fixedCost   = %s (current %s)\n", solidityInt(c.relay.Fixed), solidityInt(relayOverheadFixed))
+	fmt.Fprintf(w, "  dynamicCost = %s * _numHashes (current %s)\n", solidityInt(c.relay.Linear), solidityInt(relayOverheadPerHash))
+	fmt.Fprintf(w, "  quadratic   = %s\n", quadraticTerm(c.relay.Quadratic))
+
+	fmt.Fprintln(w, "\nSuggested claimOverhead:")
+	if c.claim0Set {
+		fmt.Fprintf(w, "  _numHashes == 0: fixedCost = %s (current %s)\n", solidityInt(c.claim0), solidityInt(c.currentClaim[0]))
+	}
+	if c.claim1Set {
+		fmt.Fprintf(w, "  _numHashes == 1: fixedCost + dynamicCost = %s (current %s)\n", solidityInt(c.claim1), solidityInt(c.currentClaim[1]))
+	}
+	if c.claimFit {
+		fmt.Fprintf(w, "  _numHashes >= 2: fixedCost   = %s\n", solidityInt(c.claim.Fixed))
+		fmt.Fprintf(w, "                   dynamicCost = %s * _numHashes\n", solidityInt(c.claim.Linear))
+		fmt.Fprintf(w, "                   quadratic   = %s\n", quadraticTerm(c.claim.Quadratic))
+	}
+
+	fmt.Fprintln(w, "\nCurrent, needed and fitted overhead per nested message count, the residual is needed minus fitted (in gas):")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "nested\t\trelay current\tneeded\tfitted\tresidual\t\tclaim current\tneeded\tfitted\tresidual\t")
+	for _, n := range nestedCounts(c.relayPts) {
+		relayNeeded, _ := meanOverhead(c.relayPts, n)
+		relayFitted := c.relay.at(n)
+		fmt.Fprintf(tw, "%d\t\t%.0f\t%.0f\t%.0f\t%+.1f\t", n, currentRelayOverhead(n), relayNeeded, relayFitted, relayNeeded-relayFitted)
+		claimNeeded, _ := meanOverhead(c.claimPts, n)
+		if claimFitted, ok := c.claimAt(n); ok {
+			fmt.Fprintf(tw, "\t%.0f\t%.0f\t%.0f\t%+.1f\t\n", c.currentClaim[n], claimNeeded, claimFitted, claimNeeded-claimFitted)
+		} else {
+			fmt.Fprintf(tw, "\t%.0f\t-\t-\t-\t\n", c.currentClaim[n])
+		}
+	}
+	return tw.Flush()
+}
+
+// nestedCounts returns the distinct nested message counts of the points in increasing order
+func nestedCounts(points []overheadPoint) []int {
+	seen := make(map[int]bool)
+	var counts []int
+	for _, p := range points {
+		if !seen[p.numHashes] {
+			seen[p.numHashes] = true
+			counts = append(counts, p.numHashes)
+		}
+	}
+	sort.Ints(counts)
+	return counts
+}
+
+// quadraticTerm expresses a quadratic coefficient the way claimOverhead does, as a right shift of _numHashes²
+func quadraticTerm(coefficient float64) string {
+	if coefficient <= 0 {
+		return fmt.Sprintf("none (fitted coefficient %.6f)", coefficient)
+	}
+	shift := math.Round(math.Log2(1 / coefficient))
+	if shift < 0 {
+		return fmt.Sprintf("%.2f * _numHashes * _numHashes", coefficient)
+	}
+	return fmt.Sprintf("(_numHashes * _numHashes) >> %.0f (fitted coefficient %.6f)", shift, coefficient)
+}
+
+// solidityInt formats a gas amount rounded to an integer with the underscore separators used in GasTank.sol
+func solidityInt(v float64) string {
+	digits := strconv.FormatInt(int64(math.Round(math.Abs(v))), 10)
+	var b strings.Builder
+	if v < 0 && math.Round(v) != 0 {
+		b.WriteByte('-')
+	}
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(digit)
+	}
+	return b.String()
+}
+
+// runCalibration fits the overhead constants to the given results files, or to a new sweep when there are none.
+// The current claim overhead is read from the GasTank of the origin chain, where gasanalysis claims.
+func runCalibration(cfg *Config, opts gasAnalysisOptions, files []string) error {
+	var results []*gasAnalysisResults
+	if len(files) == 0 {
+		if err := opts.validate(); err != nil {
+			return err
+		}
+		sweep, filePaths, err := runGasAnalysisSweep(cfg, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\nSweep results saved to %s\n", strings.Join(filePaths, ", "))
+		results = append(results, sweep)
+	}
+	for _, file := range files {
+		loaded, err := loadGasAnalysisResults(file)
+		if err != nil {
+			return err
+		}
+		results = append(results, loaded)
+	}
+
+	contracts, err := loadSupersimContracts(cfg.ContractsFile)
+	if err != nil {
+		return err
+	}
+	chains, err := dialGasTankChains(cfg, contracts)
+	if err != nil {
+		return err
+	}
+	currentClaim, err := readClaimOverheads(context.Background(), chains[cfg.Origin.ChainID], sampledNestedCounts(results))
+	if err != nil {
+		return fmt.Errorf("failed to read the current claim overhead: %w", err)
+	}
+
+	c, err := calibrate(results, currentClaim)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	return c.write(os.Stdout)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// TestReadClaimOverheads reads the claim overhead of the GasTank on the simulated origin
+func TestReadClaimOverheads(t *testing.T) {
+	h := newTestHarness(t)
+	contracts, err := loadSupersimContracts(h.cfg.ContractsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chains, err := dialGasTankChains(h.cfg, contracts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := readClaimOverheads(context.Background(), chains[h.cfg.Origin.ChainID], []int{0, 1, 2})
+	if err != nil {
+		t.Fatalf("readClaimOverheads: %v", err)
+	}
+	// The constants of GasTank.claimOverhead
+	want := map[int]float64{0: 151_764, 1: 153_500 + 23_300, 2: 133_900 + 2*23_340 + (2*2)>>10}
+	for numHashes, overhead := range want {
+		if got[numHashes] != overhead {
+			t.Errorf("claim overhead of %d hashes = %.0f, want %.0f", numHashes, got[numHashes], overhead)
+		}
+	}
+}
+
+func TestSolveLinearSystem(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       [3][3]float64
+		b       [3]float64
+		size    int
+		want    [3]float64
+		wantErr bool
+	}{
+		{
+			name: "exact 3x3",
+			a:    [3][3]float64{{2, 1, -1}, {-3, -1, 2}, {-2, 1, 2}},
+			b:    [3]float64{8, -11, -3},
+			size: 3,
+			want: [3]float64{2, 3, -1},
+		},
+		{
+			name: "zero on the diagonal needs pivoting",
+			a:    [3][3]float64{{0, 1, 0}, {1, 0, 0}, {0, 0, 1}},
+			b:    [3]float64{2, 3, 4},
+			size: 3,
+			want: [3]float64{3, 2, 4},
+		},
+		{
+			name: "2x2 ignores the third equation",
+			a:    [3][3]float64{{1, 1, 9}, {1, -1, 9}, {9, 9, 0}},
+			b:    [3]float64{5, 1, 9},
+			size: 2,
+			want: [3]float64{3, 2, 0},
+		},
+		{
+			name:    "singular",
+			a:       [3][3]float64{{1, 2, 3}, {2, 4, 6}, {0, 0, 1}},
+			b:       [3]float64{1, 2, 3},
+			size:    3,
+			wantErr: true,
+		},
+		{
+			name:    "singular 1x1",
+			a:       [3][3]float64{},
+			b:       [3]float64{1},
+			size:    1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := solveLinearSystem(tt.a, tt.b, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("solveLinearSystem error = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && !approxEqual(got, tt.want) {
+				t.Errorf("solveLinearSystem = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitOverhead(t *testing.T) {
+	quadratic := func(counts ...int) []overheadPoint {
+		var points []overheadPoint
+		for _, n := range counts {
+			points = append(points, overheadPoint{n, overheadFit{Fixed: 130_000, Linear: 23_000, Quadratic: 0.25}.at(n)})
+		}
+		return points
+	}
+	tests := []struct {
+		name    string
+		points  []overheadPoint
+		want    overheadFit
+		wantErr bool
+	}{
+		{
+			name:   "exact quadratic fit",
+			points: quadratic(2, 5, 10, 20, 35),
+			want:   overheadFit{Fixed: 130_000, Linear: 23_000, Quadratic: 0.25},
+		},
+		{
+			name:   "least squares of repeated samples",
+			points: []overheadPoint{{0, 90}, {0, 110}, {1, 200}, {1, 220}},
+			want:   overheadFit{Fixed: 100, Linear: 110},
+		},
+		{
+			name:   "single nested message count fits the mean",
+			points: []overheadPoint{{4, 1000}, {4, 1010}, {4, 1020}},
+			want:   overheadFit{Fixed: 1010},
+		},
+		{
+			name:    "no samples",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fitOverhead(tt.points)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fitOverhead error = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && !approxEqual([3]float64{got.Fixed, got.Linear, got.Quadratic}, [3]float64{tt.want.Fixed, tt.want.Linear, tt.want.Quadratic}) {
+				t.Errorf("fitOverhead = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func approxEqual(a, b [3]float64) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-6*math.Max(1, math.Abs(b[i])) {
+			return false
+		}
+	}
+	return true
+}
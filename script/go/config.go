@@ -0,0 +1,354 @@
+// This file contains the configuration layer shared by every script: RPC endpoints, chain IDs and keys.
+// Values are resolved in order of increasing precedence: built-in supersim defaults, a TOML config file,
+// SUPERSIM_* environment variables and finally command line flags.
+package main
+
+import (
+	"crypto/ecdsa"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ChainConfig holds the connection details of a single L2 chain
+type ChainConfig struct {
+	RPC     string `toml:"rpc"`
+	ChainID uint64 `toml:"chain_id"`
+}
+
+// TxConfig holds the settings of the transactions sent by the scripts, see TxSender
+type TxConfig struct {
+	// FeeStrategy is zero-tip (default, cost parity with the GasTank accounting), priority or legacy
+	FeeStrategy string `toml:"fee_strategy"`
+	// FeeCapMultiplier is the number of base fees the fee cap allows for
+	FeeCapMultiplier uint64 `toml:"fee_cap_multiplier"`
+	// TipWei is the priority tip of the priority strategy, 0 to use the node's suggestion
+	TipWei uint64 `toml:"tip_wei"`
+	// GasMargin is the percentage added on top of the estimated gas
+	GasMargin uint64 `toml:"gas_margin"`
+	// GasLimit replaces the gas estimation when non-zero
+	GasLimit uint64 `toml:"gas_limit"`
+	// Timeout bounds the time waited for a transaction to be mined
+	Timeout time.Duration `toml:"timeout"`
+	// ResubmitAfter is the time after which a transaction not mined yet is replaced with bumped fees, 0 to never replace
+	ResubmitAfter time.Duration `toml:"resubmit_after"`
+	// FeeBump is the percentage by which every replacement raises the fee cap and tip, at least 10
+	FeeBump uint64 `toml:"fee_bump"`
+	// MaxFeeBump is the percentage the replacements may raise the original fee cap or gas price by in total
+	MaxFeeBump uint64 `toml:"max_fee_bump"`
+}
+
+// Config holds every setting the scripts need to talk to a supersim instance
+type Config struct {
+	// AdminRPC is the supersim admin endpoint serving admin_getAccessListForIdentifier
+	AdminRPC string `toml:"admin_rpc"`
+	// MockAdmin serves admin_getAccessListForIdentifier in process instead of using AdminRPC, see mockadmin
+	MockAdmin bool `toml:"mock_admin"`
+	// AccessListSource is where relay and claim access lists come from: admin, local, auto (default) or verify
+	AccessListSource string `toml:"access_list_source"`
+	// ContractsFile is the deployment output of SetupSupersim.s.sol
+	ContractsFile string `toml:"contracts_file"`
+	// Origin is the chain messages are sent from (901 by default)
+	Origin ChainConfig `toml:"origin"`
+	// Destination is the chain messages are relayed to (902 by default)
+	Destination ChainConfig `toml:"destination"`
+	// GasProviderKey funds the GasTank and sends the original messages (anvil account 0 by default)
+	GasProviderKey string `toml:"gas_provider_key"`
+	// RelayerKey relays messages and claims the repayments (anvil account 1 by default)
+	RelayerKey string `toml:"relayer_key"`
+	// Tx configures gas estimation, fees and timeouts of the transactions sent
+	Tx TxConfig `toml:"tx"`
+}
+
+// defaultConfig returns the configuration matching a supersim instance started without arguments
+func defaultConfig() *Config {
+	// Get the path of the currently running file
+	_, b, _, _ := runtime.Caller(0)
+	basepath := filepath.Dir(b)
+
+	return &Config{
+		AdminRPC:         "http://127.0.0.1:8420",
+		AccessListSource: accessListSourceAuto,
+		ContractsFile:    filepath.Join(basepath, "supersim-contracts.json"),
+		Origin:           ChainConfig{RPC: "http://127.0.0.1:9545", ChainID: 901},
+		Destination:      ChainConfig{RPC: "http://127.0.0.1:9546", ChainID: 902},
+		GasProviderKey:   "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+		RelayerKey:       "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
+		Tx: TxConfig{
+			FeeStrategy:      feeStrategyZeroTip,
+			FeeCapMultiplier: 2,
+			GasMargin:        20,
+			Timeout:          2 * time.Minute,
+			ResubmitAfter:    30 * time.Second,
+			FeeBump:          minFeeBump,
+			MaxFeeBump:       100,
+		},
+	}
+}
+
+// configFlags holds the command line flags shared by every subcommand
+type configFlags struct {
+	path               *string
+	adminRPC           *string
+	mockAdmin          *bool
+	accessListSource   *string
+	contractsFile      *string
+	originRPC          *string
+	originChainID      *uint64
+	destinationRPC     *string
+	destinationChainID *uint64
+	gasProviderKey     *string
+	relayerKey         *string
+	feeStrategy        *string
+	feeCapMultiplier   *uint64
+	tipWei             *uint64
+	gasMargin          *uint64
+	gasLimit           *uint64
+	txTimeout          *time.Duration
+	resubmitAfter      *time.Duration
+	feeBump            *uint64
+	maxFeeBump         *uint64
+}
+
+// addConfigFlags registers the shared configuration flags on a subcommand's flag set
+func addConfigFlags(fs *flag.FlagSet) *configFlags {
+	return &configFlags{
+		path:               fs.String("config", "", "Path to a TOML config file (env: SUPERSIM_CONFIG)."),
+		adminRPC:           fs.String("adminRPC", "", "Supersim admin RPC URL (env: SUPERSIM_ADMIN_RPC)."),
+		mockAdmin:          fs.Bool("mockAdmin", false, "Compute access lists with an in-process admin RPC instead of supersim's (env: SUPERSIM_MOCK_ADMIN)."),
+		accessListSource:   fs.String("accessListSource", "", "Source of the access lists: admin, local, auto (admin, local when it fails) or verify (admin checked against local) (env: SUPERSIM_ACCESS_LIST_SOURCE)."),
+		contractsFile:      fs.String("contractsFile", "", "Path to supersim-contracts.json (env: SUPERSIM_CONTRACTS_FILE)."),
+		originRPC:          fs.String("originRPC", "", "Origin chain RPC URL (env: SUPERSIM_ORIGIN_RPC)."),
+		originChainID:      fs.Uint64("originChainId", 0, "Origin chain ID (env: SUPERSIM_ORIGIN_CHAIN_ID)."),
+		destinationRPC:     fs.String("destinationRPC", "", "Destination chain RPC URL (env: SUPERSIM_DESTINATION_RPC)."),
+		destinationChainID: fs.Uint64("destinationChainId", 0, "Destination chain ID (env: SUPERSIM_DESTINATION_CHAIN_ID)."),
+		gasProviderKey:     fs.String("gasProviderKey", "", "Hex private key of the gas provider (env: SUPERSIM_GAS_PROVIDER_KEY)."),
+		relayerKey:         fs.String("relayerKey", "", "Hex private key of the relayer (env: SUPERSIM_RELAYER_KEY)."),
+		feeStrategy:        fs.String("feeStrategy", "", "Transaction fee strategy: zero-tip, priority or legacy (env: SUPERSIM_FEE_STRATEGY)."),
+		feeCapMultiplier:   fs.Uint64("feeCapMultiplier", 0, "Number of base fees the fee cap allows for (env: SUPERSIM_FEE_CAP_MULTIPLIER)."),
+		tipWei:             fs.Uint64("tipWei", 0, "Priority tip in wei for the priority strategy, 0 for the node's suggestion (env: SUPERSIM_TIP_WEI)."),
+		gasMargin:          fs.Uint64("gasMargin", 0, "Percentage added on top of the estimated gas (env: SUPERSIM_GAS_MARGIN)."),
+		gasLimit:           fs.Uint64("gasLimit", 0, "Fixed gas limit replacing the estimation (env: SUPERSIM_GAS_LIMIT)."),
+		txTimeout:          fs.Duration("txTimeout", 0, "Maximum time waited for a transaction to be mined (env: SUPERSIM_TX_TIMEOUT)."),
+		resubmitAfter:      fs.Duration("resubmitAfter", 0, "Time after which a pending transaction is replaced with bumped fees, 0 to never replace (env: SUPERSIM_RESUBMIT_AFTER)."),
+		feeBump:            fs.Uint64("feeBump", 0, "Percentage every replacement raises the fee cap and tip by, at least 10 (env: SUPERSIM_FEE_BUMP)."),
+		maxFeeBump:         fs.Uint64("maxFeeBump", 0, "Percentage replacements may raise the original fee cap or gas price by in total (env: SUPERSIM_MAX_FEE_BUMP)."),
+	}
+}
+
+// load resolves the configuration from defaults, the config file, the environment and the flags set on fs.
+// It must be called after fs.Parse.
+func (f *configFlags) load(fs *flag.FlagSet) (*Config, error) {
+	cfg := defaultConfig()
+
+	path := *f.path
+	if path == "" {
+		path = os.Getenv("SUPERSIM_CONFIG")
+	}
+	if path != "" {
+		if _, err := toml.DecodeFile(path, cfg); err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	// Only flags explicitly passed on the command line override the lower layers
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "adminRPC":
+			cfg.AdminRPC = *f.adminRPC
+		case "mockAdmin":
+			cfg.MockAdmin = *f.mockAdmin
+		case "accessListSource":
+			cfg.AccessListSource = *f.accessListSource
+		case "contractsFile":
+			cfg.ContractsFile = *f.contractsFile
+		case "originRPC":
+			cfg.Origin.RPC = *f.originRPC
+		case "originChainId":
+			cfg.Origin.ChainID = *f.originChainID
+		case "destinationRPC":
+			cfg.Destination.RPC = *f.destinationRPC
+		case "destinationChainId":
+			cfg.Destination.ChainID = *f.destinationChainID
+		case "gasProviderKey":
+			cfg.GasProviderKey = *f.gasProviderKey
+		case "relayerKey":
+			cfg.RelayerKey = *f.relayerKey
+		case "feeStrategy":
+			cfg.Tx.FeeStrategy = *f.feeStrategy
+		case "feeCapMultiplier":
+			cfg.Tx.FeeCapMultiplier = *f.feeCapMultiplier
+		case "tipWei":
+			cfg.Tx.TipWei = *f.tipWei
+		case "gasMargin":
+			cfg.Tx.GasMargin = *f.gasMargin
+		case "gasLimit":
+			cfg.Tx.GasLimit = *f.gasLimit
+		case "txTimeout":
+			cfg.Tx.Timeout = *f.txTimeout
+		case "resubmitAfter":
+			cfg.Tx.ResubmitAfter = *f.resubmitAfter
+		case "feeBump":
+			cfg.Tx.FeeBump = *f.feeBump
+		case "maxFeeBump":
+			cfg.Tx.MaxFeeBump = *f.maxFeeBump
+		}
+	})
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyEnv overrides the configuration with any SUPERSIM_* environment variables that are set
+func (c *Config) applyEnv() error {
+	stringVars := map[string]*string{
+		"SUPERSIM_ADMIN_RPC":          &c.AdminRPC,
+		"SUPERSIM_ACCESS_LIST_SOURCE": &c.AccessListSource,
+		"SUPERSIM_CONTRACTS_FILE":     &c.ContractsFile,
+		"SUPERSIM_ORIGIN_RPC":         &c.Origin.RPC,
+		"SUPERSIM_DESTINATION_RPC":    &c.Destination.RPC,
+		"SUPERSIM_GAS_PROVIDER_KEY":   &c.GasProviderKey,
+		"SUPERSIM_RELAYER_KEY":        &c.RelayerKey,
+		"SUPERSIM_FEE_STRATEGY":       &c.Tx.FeeStrategy,
+	}
+	for name, dst := range stringVars {
+		if v, ok := os.LookupEnv(name); ok {
+			*dst = v
+		}
+	}
+
+	uintVars := map[string]*uint64{
+		"SUPERSIM_ORIGIN_CHAIN_ID":      &c.Origin.ChainID,
+		"SUPERSIM_DESTINATION_CHAIN_ID": &c.Destination.ChainID,
+		"SUPERSIM_FEE_CAP_MULTIPLIER":   &c.Tx.FeeCapMultiplier,
+		"SUPERSIM_TIP_WEI":              &c.Tx.TipWei,
+		"SUPERSIM_GAS_MARGIN":           &c.Tx.GasMargin,
+		"SUPERSIM_GAS_LIMIT":            &c.Tx.GasLimit,
+		"SUPERSIM_FEE_BUMP":             &c.Tx.FeeBump,
+		"SUPERSIM_MAX_FEE_BUMP":         &c.Tx.MaxFeeBump,
+	}
+	for name, dst := range uintVars {
+		if v, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %w", name, v, err)
+			}
+			*dst = parsed
+		}
+	}
+
+	boolVars := map[string]*bool{
+		"SUPERSIM_MOCK_ADMIN": &c.MockAdmin,
+	}
+	for name, dst := range boolVars {
+		if v, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %w", name, v, err)
+			}
+			*dst = parsed
+		}
+	}
+
+	durationVars := map[string]*time.Duration{
+		"SUPERSIM_TX_TIMEOUT":     &c.Tx.Timeout,
+		"SUPERSIM_RESUBMIT_AFTER": &c.Tx.ResubmitAfter,
+	}
+	for name, dst := range durationVars {
+		if v, ok := os.LookupEnv(name); ok {
+			parsed, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %w", name, v, err)
+			}
+			*dst = parsed
+		}
+	}
+	return nil
+}
+
+// validate checks that the resolved configuration is usable
+func (c *Config) validate() error {
+	if c.Origin.ChainID == 0 || c.Destination.ChainID == 0 {
+		return fmt.Errorf("origin and destination chain IDs must be set")
+	}
+	if c.Origin.ChainID == c.Destination.ChainID {
+		return fmt.Errorf("origin and destination chain IDs must differ, both are %d", c.Origin.ChainID)
+	}
+	if c.Origin.RPC == "" || c.Destination.RPC == "" {
+		return fmt.Errorf("origin and destination RPC URLs must be set")
+	}
+	switch c.AccessListSource {
+	case accessListSourceAdmin, accessListSourceLocal, accessListSourceAuto, accessListSourceVerify:
+	default:
+		return fmt.Errorf("unknown access list source %q, expected %s, %s, %s or %s", c.AccessListSource, accessListSourceAdmin, accessListSourceLocal, accessListSourceAuto, accessListSourceVerify)
+	}
+	if _, err := newFeeStrategy(c.Tx); err != nil {
+		return err
+	}
+	if c.Tx.FeeCapMultiplier == 0 {
+		return fmt.Errorf("fee cap multiplier must be at least 1")
+	}
+	if c.Tx.FeeBump < minFeeBump {
+		return fmt.Errorf("fee bump must be at least %d%% for nodes to accept the replacements, got %d%%", minFeeBump, c.Tx.FeeBump)
+	}
+	return nil
+}
+
+// Chains returns every configured chain, origin first
+func (c *Config) Chains() []ChainConfig {
+	return []ChainConfig{c.Origin, c.Destination}
+}
+
+// Chain returns the configured chain with the given chain ID
+func (c *Config) Chain(chainID uint64) (ChainConfig, error) {
+	for _, chain := range c.Chains() {
+		if chain.ChainID == chainID {
+			return chain, nil
+		}
+	}
+	return ChainConfig{}, fmt.Errorf("chain %d is not configured", chainID)
+}
+
+// GasProvider parses the gas provider private key
+func (c *Config) GasProvider() (*ecdsa.PrivateKey, error) {
+	return parsePrivateKey(c.GasProviderKey)
+}
+
+// Relayer parses the relayer private key
+func (c *Config) Relayer() (*ecdsa.PrivateKey, error) {
+	return parsePrivateKey(c.RelayerKey)
+}
+
+// ID returns the chain ID as a big.Int
+func (c ChainConfig) ID() *big.Int {
+	return new(big.Int).SetUint64(c.ChainID)
+}
+
+// Dial connects to the chain's RPC endpoint
+func (c ChainConfig) Dial() (*ethclient.Client, error) {
+	client, err := ethclient.Dial(c.RPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to chain %d at %s: %w", c.ChainID, c.RPC, err)
+	}
+	return client, nil
+}
+
+func parsePrivateKey(hexKey string) (*ecdsa.PrivateKey, error) {
+	return crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+}
@@ -0,0 +1,440 @@
+// This script measures how far the GasTank's relay and claim cost accounting is from the gas actually used,
+// for a matrix of nested message counts, and saves every sample to the results directory.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Failure policies of gasanalysis, applied when a run fails
+const (
+	// failurePolicyContinue records the failure and moves on to the next run
+	failurePolicyContinue = "continue"
+	// failurePolicyRetry reruns a failed run up to the configured number of retries, then continues
+	failurePolicyRetry = "retry"
+	// failurePolicyAbort saves the samples gathered so far and stops
+	failurePolicyAbort = "abort"
+)
+
+// defaultNestedMessages is the nested message matrix of gasanalysis
+const defaultNestedMessages = "0,1,2,5,10,15,30,35"
+
+// gasAnalysisOptions configures the runs of gasanalysis
+type gasAnalysisOptions struct {
+	// NestedMessages are the nested message counts measured, in order
+	NestedMessages []int
+	// Repetitions is the number of recorded runs per nested message count
+	Repetitions int
+	// Warmup is the number of unrecorded runs per nested message count before the recorded ones
+	Warmup        int
+	OnFailure     string
+	Retries       int
+	DepositTarget depositTarget
+	// Baseline is a results file the run is compared with, none when empty
+	Baseline string
+	// Tolerance is the growth of the mean under-reimbursement, in gas units, tolerated against the baseline
+	Tolerance float64
+	// Formats are the output formats the results are saved in besides the JSON file, see parseOutputFormats
+	Formats []string
+}
+
+// gasAnalysisSchemaVersion is the version of the results file layout, described by results/gas_analysis.schema.json.
+// Files without a schemaVersion come in two older layouts: version 1 already holds the cases with their samples and
+// failures, but records only the relay and claim gas delta of each sample, and the original layout holds a single
+// relay and claim gas delta per nested message count.
+const gasAnalysisSchemaVersion = 2
+
+// TxCostBreakdown is the cost accounting of a relay or claim transaction
+type TxCostBreakdown struct {
+	TxHash  common.Hash `json:"txHash"`
+	GasUsed uint64      `json:"gasUsed"`
+	// CalculatedGas is the cost declared in the event divided by the base fee, the gas the GasTank paid for
+	CalculatedGas *big.Int `json:"calculatedGas"`
+	// GasDelta is CalculatedGas minus GasUsed, negative when the transaction sender is under-reimbursed
+	GasDelta *big.Int `json:"gasDelta"`
+	BaseFee  *big.Int `json:"baseFee"`
+	// ActualCost is the L2 execution cost paid, gasUsed times the effective gas price
+	ActualCost *big.Int `json:"actualCost"`
+	// EventCost is the cost declared in the RelayedMessageGasReceipt or Claimed event
+	EventCost *big.Int `json:"eventCost"`
+	// L1Fee is the data availability fee charged for the transaction, from its receipt or, on chains whose receipts
+	// do not report it, GasPriceOracle.getL1Fee of the unsigned transaction at its block
+	L1Fee *big.Int `json:"l1Fee"`
+	// Profit is EventCost minus ActualCost and L1Fee, negative for a loss
+	Profit       *big.Int `json:"profit"`
+	CalldataSize int      `json:"calldataSize"`
+}
+
+// GasAnalysisSample is the cost accounting of the relay and claim of one message
+type GasAnalysisSample struct {
+	MessageHash common.Hash     `json:"messageHash"`
+	Relay       TxCostBreakdown `json:"relay"`
+	Claim       TxCostBreakdown `json:"claim"`
+}
+
+// gasAnalysisFailure is a run that failed, kept in the results so that skipped samples are visible
+type gasAnalysisFailure struct {
+	Repetition int    `json:"repetition"`
+	Attempt    int    `json:"attempt"`
+	Error      string `json:"error"`
+}
+
+// gasAnalysisCase holds every sample measured for one nested message count
+type gasAnalysisCase struct {
+	NestedMessages int                  `json:"nestedMessages"`
+	Samples        []*GasAnalysisSample `json:"samples"`
+	Failures       []gasAnalysisFailure `json:"failures,omitempty"`
+	// Stats summarizes the samples, it is omitted when every run failed
+	Stats *gasDeltaStats `json:"stats,omitempty"`
+}
+
+// gasAnalysisResults is the content of a results/gas_analysis_*.json file
+type gasAnalysisResults struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	Origin        uint64             `json:"originChainId"`
+	Destination   uint64             `json:"destinationChainId"`
+	Repetitions   int                `json:"repetitions"`
+	Warmup        int                `json:"warmup"`
+	Cases         []*gasAnalysisCase `json:"cases"`
+}
+
+// parseNestedMessages parses a comma separated list of nested message counts and ranges, e.g. "0,1,2,5-10"
+// or "0-35:5" for every fifth count from 0 to 35
+func parseNestedMessages(s string) ([]int, error) {
+	var counts []int
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		rangeSpec, stepSpec, hasStep := strings.Cut(entry, ":")
+		startSpec, endSpec, isRange := strings.Cut(rangeSpec, "-")
+		if !isRange {
+			if hasStep {
+				return nil, fmt.Errorf("invalid nested message count %q: a step requires a range", entry)
+			}
+			endSpec = startSpec
+		}
+
+		start, err := strconv.Atoi(startSpec)
+		if err != nil || start < 0 {
+			return nil, fmt.Errorf("invalid nested message count %q", entry)
+		}
+		end, err := strconv.Atoi(endSpec)
+		if err != nil || end < start {
+			return nil, fmt.Errorf("invalid nested message range %q", entry)
+		}
+		step := 1
+		if hasStep {
+			step, err = strconv.Atoi(stepSpec)
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step in nested message range %q", entry)
+			}
+		}
+		for count := start; count <= end; count += step {
+			counts = append(counts, count)
+		}
+	}
+	return counts, nil
+}
+
+// validate checks the options set from the command line
+func (o gasAnalysisOptions) validate() error {
+	if len(o.NestedMessages) == 0 {
+		return fmt.Errorf("at least one nested message count is required")
+	}
+	if o.Repetitions < 1 {
+		return fmt.Errorf("repetitions must be at least 1, got %d", o.Repetitions)
+	}
+	if o.Warmup < 0 || o.Retries < 0 || o.Tolerance < 0 {
+		return fmt.Errorf("warmup, retries and tolerance cannot be negative")
+	}
+	switch o.OnFailure {
+	case failurePolicyContinue, failurePolicyRetry, failurePolicyAbort:
+		return nil
+	}
+	return fmt.Errorf("unknown failure policy %q, expected %s, %s or %s", o.OnFailure, failurePolicyContinue, failurePolicyRetry, failurePolicyAbort)
+}
+
+func runGasAnalysis(cfg *Config, opts gasAnalysisOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	// Load the baseline first to fail before spending time on the runs
+	var baseline *gasAnalysisResults
+	if opts.Baseline != "" {
+		var err error
+		if baseline, err = loadGasAnalysisResults(opts.Baseline); err != nil {
+			return fmt.Errorf("failed to load baseline: %w", err)
+		}
+	}
+	results, filePaths, err := runGasAnalysisSweep(cfg, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if err := writeStatsTable(os.Stdout, results.Cases); err != nil {
+		return err
+	}
+
+	failures := 0
+	for _, result := range results.Cases {
+		failures += len(result.Failures)
+	}
+	if failures > 0 {
+		fmt.Printf("\n⚠️ %d runs failed, see the failures recorded in the results\n", failures)
+	}
+	fmt.Printf("\n✅ Gas analysis complete. Results saved to %s\n", strings.Join(filePaths, ", "))
+
+	if baseline == nil {
+		return nil
+	}
+	fmt.Printf("\nComparison with baseline %s:\n", opts.Baseline)
+	comparisons := compareWithBaseline(baseline, results, opts.Tolerance)
+	regressions, err := writeBaselineReport(os.Stdout, comparisons, opts.Tolerance)
+	if err != nil {
+		return err
+	}
+	if len(comparisons) == 0 {
+		return fmt.Errorf("baseline %s has no nested message count in common with the run", opts.Baseline)
+	}
+	if regressions > 0 {
+		return fmt.Errorf("%d relay or claim under-reimbursements regressed by more than %.0f gas units against the baseline", regressions, opts.Tolerance)
+	}
+	return nil
+}
+
+// runGasAnalysisSweep measures every nested message count and saves the results, returning them along with the
+// paths of the results files
+func runGasAnalysisSweep(cfg *Config, opts gasAnalysisOptions) (*gasAnalysisResults, []string, error) {
+	results := &gasAnalysisResults{
+		SchemaVersion: gasAnalysisSchemaVersion,
+		CreatedAt:     time.Now().UTC(),
+		Origin:        cfg.Origin.ChainID,
+		Destination:   cfg.Destination.ChainID,
+		Repetitions:   opts.Repetitions,
+		Warmup:        opts.Warmup,
+	}
+
+	runErr := func() error {
+		for _, nested := range opts.NestedMessages {
+			logfIf(true, "\n--- Running for %d nested messages ---\n", nested)
+			for i := 1; i <= opts.Warmup; i++ {
+				if _, err := gasTankRelay(cfg, int64(nested), opts.DepositTarget, false, false); err != nil {
+					log.Printf("Warm-up run %d/%d for %d nested messages failed: %v", i, opts.Warmup, nested, err)
+				}
+			}
+
+			result := &gasAnalysisCase{NestedMessages: nested, Samples: []*GasAnalysisSample{}}
+			results.Cases = append(results.Cases, result)
+			for repetition := 1; repetition <= opts.Repetitions; repetition++ {
+				sample, err := runGasAnalysisSample(cfg, opts, nested, repetition, result)
+				if err != nil {
+					return err
+				}
+				if sample != nil {
+					result.Samples = append(result.Samples, sample)
+				}
+			}
+		}
+		return nil
+	}()
+
+	for _, result := range results.Cases {
+		result.Stats = computeCaseStats(result.Samples)
+	}
+
+	// Save the samples gathered so far even when aborting
+	filePaths, err := writeGasAnalysisResults(results, opts.Formats)
+	if err != nil {
+		return nil, nil, err
+	}
+	if runErr != nil {
+		return nil, nil, fmt.Errorf("%w (partial results saved to %s)", runErr, strings.Join(filePaths, ", "))
+	}
+	return results, filePaths, nil
+}
+
+// runGasAnalysisSample measures one repetition, recording its failed attempts in result. It returns a nil
+// sample when the run failed and the failure policy lets the analysis continue.
+func runGasAnalysisSample(cfg *Config, opts gasAnalysisOptions, nested, repetition int, result *gasAnalysisCase) (*GasAnalysisSample, error) {
+	attempts := 1
+	if opts.OnFailure == failurePolicyRetry {
+		attempts += opts.Retries
+	}
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		sample, err := gasTankRelay(cfg, int64(nested), opts.DepositTarget, false, false)
+		if err == nil {
+			return sample, nil
+		}
+
+		log.Printf("Run %d/%d (attempt %d/%d) for %d nested messages failed: %v", repetition, opts.Repetitions, attempt, attempts, nested, err)
+		result.Failures = append(result.Failures, gasAnalysisFailure{Repetition: repetition, Attempt: attempt, Error: err.Error()})
+		if opts.OnFailure == failurePolicyAbort {
+			return nil, fmt.Errorf("run %d for %d nested messages failed: %w", repetition, nested, err)
+		}
+	}
+	return nil, nil
+}
+
+// resultsDir returns the results directory next to the scripts' sources
+func resultsDir() string {
+	// Get the path of the currently running file
+	_, b, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(b), "results")
+}
+
+// writeGasAnalysisResults saves the results to a timestamped JSON file in the results directory, which baseline,
+// calibrate, gasplot and export read, and to a file of the same name per additional output format
+func writeGasAnalysisResults(results *gasAnalysisResults, formats []string) ([]string, error) {
+	// Create results directory if it doesn't exist
+	dir := resultsDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create results directory: %w", err)
+	}
+
+	// Generate timestamped filenames
+	timestamp := fmt.Sprintf("%d", time.Now().Unix())
+	var filePaths []string
+	for _, format := range append([]string{formatJSON}, formats...) {
+		// --format json names the file written anyway
+		if format == formatJSON && len(filePaths) > 0 {
+			continue
+		}
+		filePath := filepath.Join(dir, fmt.Sprintf("gas_analysis_%s%s", timestamp, resultsFormatExtensions[format]))
+		if err := writeResultsFile(filePath, results, format); err != nil {
+			return nil, err
+		}
+		filePaths = append(filePaths, filePath)
+	}
+	return filePaths, nil
+}
+
+// newTxCostBreakdown computes the cost accounting of a relay or claim transaction from its receipt and the cost
+// declared in its event
+func newTxCostBreakdown(ctx context.Context, client *ethclient.Client, receipt *types.Receipt, eventCost *big.Int) (*TxCostBreakdown, error) {
+	header, err := client.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s of transaction %s: %w", receipt.BlockNumber, receipt.TxHash.Hex(), err)
+	}
+	tx, _, err := client.TransactionByHash(ctx, receipt.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %s: %w", receipt.TxHash.Hex(), err)
+	}
+	// op-geth reports the L1 fee it charged in the receipt
+	l1Fee := receipt.L1Fee
+	if l1Fee == nil {
+		if l1Fee, err = getL1Fee(ctx, client, tx, receipt.BlockNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	calculatedGas := new(big.Int).Div(eventCost, header.BaseFee)
+	actualCost := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	profit := new(big.Int).Sub(eventCost, actualCost)
+	return &TxCostBreakdown{
+		TxHash:        receipt.TxHash,
+		GasUsed:       receipt.GasUsed,
+		CalculatedGas: calculatedGas,
+		GasDelta:      new(big.Int).Sub(calculatedGas, new(big.Int).SetUint64(receipt.GasUsed)),
+		BaseFee:       header.BaseFee,
+		ActualCost:    actualCost,
+		EventCost:     eventCost,
+		L1Fee:         l1Fee,
+		Profit:        profit.Sub(profit, l1Fee),
+		CalldataSize:  len(tx.Data()),
+	}, nil
+}
+
+// getL1Fee asks the GasPriceOracle for the L1 data fee of the transaction at the given block. getL1Fee expects the
+// unsigned transaction and adds the size of a signature itself.
+func getL1Fee(ctx context.Context, client ethereum.ContractCaller, tx *types.Transaction, blockNumber *big.Int) (*big.Int, error) {
+	unsignedTx, err := unsignedTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	encodedTx, err := unsignedTx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	calldata, err := gasPriceOracleABI.Pack("getL1Fee", encodedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack getL1Fee call: %w", err)
+	}
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &gasPriceOracleAddr, Data: calldata}, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 fee of transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	unpacked, err := gasPriceOracleABI.Unpack("getL1Fee", result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack getL1Fee result: %w", err)
+	}
+	return unpacked[0].(*big.Int), nil
+}
+
+// unsignedTransaction returns a copy of the transaction without its signature
+func unsignedTransaction(tx *types.Transaction) (*types.Transaction, error) {
+	switch tx.Type() {
+	case types.LegacyTxType:
+		return types.NewTx(&types.LegacyTx{Nonce: tx.Nonce(), GasPrice: tx.GasPrice(), Gas: tx.Gas(), To: tx.To(), Value: tx.Value(), Data: tx.Data()}), nil
+	case types.AccessListTxType:
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasPrice:   tx.GasPrice(),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}), nil
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}), nil
+	}
+	return nil, fmt.Errorf("unsupported type %d of transaction %s", tx.Type(), tx.Hash().Hex())
+}
+
+// print logs the breakdown in the format of the gastank script, warning the sender about losses
+func (b *TxCostBreakdown) print(verbose bool, name, sender string) {
+	logfIf(verbose, "  - Gas Used:             %d units\n", b.GasUsed)
+	logfIf(verbose, "  - Calculated Gas:       %s units\n", b.CalculatedGas.String())
+	logfIf(verbose, "  - %s Gas Delta:       %s units\n", name, b.GasDelta.String())
+	logfIf(verbose, "  - Calldata Size:        %d bytes\n", b.CalldataSize)
+	logfIf(verbose, "\n  - Block Base Fee:       %s wei\n", b.BaseFee.String())
+	logfIf(verbose, "  - Actual Cost:          %s wei\n", b.ActualCost.String())
+	logfIf(verbose, "  - L1 Fee:               %s wei\n", b.L1Fee.String())
+	logfIf(verbose, "  - Cost declared in event:  %s wei\n", b.EventCost.String())
+
+	if verbose {
+		if b.Profit.Sign() < 0 {
+			// Using a red color for the warning
+			logfIf(verbose, "\033[31m>>>>> WARNING: %s INCURRED A LOSS of %s wei <<<<<\033[0m\n", strings.ToUpper(sender), new(big.Int).Abs(b.Profit).String())
+		} else {
+			logfIf(verbose, "%s Profit:               %s wei\n", sender, b.Profit.String())
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// This file contains the results file loader and the regression check of gasanalysis against a stored baseline.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+// loadGasAnalysisResults reads a results file of any schema version. The samples of files older than
+// gasAnalysisSchemaVersion only have their gas deltas set.
+func loadGasAnalysisResults(path string) (*gasAnalysisResults, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results file: %w", err)
+	}
+	var header struct {
+		SchemaVersion int             `json:"schemaVersion"`
+		Cases         json.RawMessage `json:"cases"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode results file %s: %w", path, err)
+	}
+	// Version 1 files predate the schemaVersion field, they are told apart from the original layout by their cases
+	version := header.SchemaVersion
+	if version == 0 && header.Cases != nil {
+		version = 1
+	}
+
+	var results *gasAnalysisResults
+	switch version {
+	case 0:
+		results, err = decodeLegacyGasAnalysisResults(data)
+	case 1:
+		results, err = decodeGasAnalysisResultsV1(data)
+	case gasAnalysisSchemaVersion:
+		results = new(gasAnalysisResults)
+		err = json.Unmarshal(data, results)
+	default:
+		return nil, fmt.Errorf("results file %s has unsupported schema version %d", path, header.SchemaVersion)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode results file %s: %w", path, err)
+	}
+
+	for _, result := range results.Cases {
+		if result.Stats == nil {
+			result.Stats = computeCaseStats(result.Samples)
+		}
+	}
+	return results, nil
+}
+
+// decodeGasAnalysisResultsV1 decodes the version 1 layout, whose samples are a relay and claim gas delta
+func decodeGasAnalysisResultsV1(data []byte) (*gasAnalysisResults, error) {
+	var v1 struct {
+		Repetitions int `json:"repetitions"`
+		Warmup      int `json:"warmup"`
+		Cases       []struct {
+			NestedMessages int `json:"nestedMessages"`
+			Samples        []struct {
+				Relay *big.Int `json:"relay"`
+				Claim *big.Int `json:"claim"`
+			} `json:"samples"`
+			Failures []gasAnalysisFailure `json:"failures"`
+		} `json:"cases"`
+	}
+	if err := json.Unmarshal(data, &v1); err != nil {
+		return nil, err
+	}
+
+	results := &gasAnalysisResults{SchemaVersion: 1, Repetitions: v1.Repetitions, Warmup: v1.Warmup}
+	for _, c := range v1.Cases {
+		result := &gasAnalysisCase{NestedMessages: c.NestedMessages, Samples: []*GasAnalysisSample{}, Failures: c.Failures}
+		for i, deltas := range c.Samples {
+			if deltas.Relay == nil || deltas.Claim == nil {
+				return nil, fmt.Errorf("sample %d of %d nested messages has no gas deltas", i, c.NestedMessages)
+			}
+			result.Samples = append(result.Samples, &GasAnalysisSample{Relay: TxCostBreakdown{GasDelta: deltas.Relay}, Claim: TxCostBreakdown{GasDelta: deltas.Claim}})
+		}
+		results.Cases = append(results.Cases, result)
+	}
+	return results, nil
+}
+
+// decodeLegacyGasAnalysisResults decodes the original layout, a relay and claim gas delta per nested message count
+func decodeLegacyGasAnalysisResults(data []byte) (*gasAnalysisResults, error) {
+	var legacy map[string]struct {
+		Relay *big.Int `json:"relay"`
+		Claim *big.Int `json:"claim"`
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return nil, err
+	}
+
+	results := &gasAnalysisResults{Repetitions: 1}
+	for key, deltas := range legacy {
+		nested, err := strconv.Atoi(key)
+		if err != nil || deltas.Relay == nil || deltas.Claim == nil {
+			return nil, fmt.Errorf("invalid entry %q", key)
+		}
+		sample := &GasAnalysisSample{Relay: TxCostBreakdown{GasDelta: deltas.Relay}, Claim: TxCostBreakdown{GasDelta: deltas.Claim}}
+		results.Cases = append(results.Cases, &gasAnalysisCase{NestedMessages: nested, Samples: []*GasAnalysisSample{sample}})
+	}
+	sort.Slice(results.Cases, func(i, j int) bool { return results.Cases[i].NestedMessages < results.Cases[j].NestedMessages })
+	return results, nil
+}
+
+// baselineComparison compares the mean gas delta of one transaction kind for a nested message count
+type baselineComparison struct {
+	NestedMessages int
+	Kind           string
+	Baseline       float64
+	Current        float64
+	// Regression is how many more gas units the sender is under-reimbursed by than in the baseline
+	Regression float64
+	Failed     bool
+}
+
+// compareWithBaseline compares the mean relay and claim gas deltas of every nested message count present in both
+// results. A comparison fails when the under-reimbursement, the part of a negative delta the sender is not
+// repaid, grew by more than tolerance gas units.
+func compareWithBaseline(baseline, current *gasAnalysisResults, tolerance float64) []baselineComparison {
+	baselineStats := make(map[int]*gasDeltaStats)
+	for _, result := range baseline.Cases {
+		if result.Stats != nil {
+			baselineStats[result.NestedMessages] = result.Stats
+		}
+	}
+
+	var comparisons []baselineComparison
+	for _, result := range current.Cases {
+		expected, ok := baselineStats[result.NestedMessages]
+		if !ok || result.Stats == nil {
+			continue
+		}
+		for _, kind := range []struct {
+			name              string
+			baseline, current sampleStats
+		}{
+			{"relay", expected.Relay, result.Stats.Relay},
+			{"claim", expected.Claim, result.Stats.Claim},
+		} {
+			regression := underReimbursement(kind.current.Mean) - underReimbursement(kind.baseline.Mean)
+			comparisons = append(comparisons, baselineComparison{
+				NestedMessages: result.NestedMessages,
+				Kind:           kind.name,
+				Baseline:       kind.baseline.Mean,
+				Current:        kind.current.Mean,
+				Regression:     regression,
+				Failed:         regression > tolerance,
+			})
+		}
+	}
+	return comparisons
+}
+
+// underReimbursement returns the gas units a sender is not repaid for, given a gas delta
+func underReimbursement(delta float64) float64 {
+	return math.Max(0, -delta)
+}
+
+// writeBaselineReport prints the comparisons as a diff table and returns the number of regressions
+func writeBaselineReport(w io.Writer, comparisons []baselineComparison, tolerance float64) (int, error) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "nested\ttx\tbaseline mean\tcurrent mean\tchange\tregression\tstatus\t")
+	failed := 0
+	for _, c := range comparisons {
+		status := "ok"
+		if c.Failed {
+			status = "REGRESSION"
+			failed++
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%.1f\t%.1f\t%+.1f\t%.1f\t%s\t\n", c.NestedMessages, c.Kind, c.Baseline, c.Current, c.Current-c.Baseline, c.Regression, status)
+	}
+	if err := tw.Flush(); err != nil {
+		return 0, err
+	}
+	if len(comparisons) == 0 {
+		fmt.Fprintln(w, "No nested message count in common with the baseline")
+	}
+	fmt.Fprintf(w, "%d of %d comparisons exceed the tolerance of %.0f gas units\n", failed, len(comparisons), tolerance)
+	return failed, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadGasAnalysisResults(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantVersion int
+		wantRelay   int64
+	}{
+		{
+			name:        "original layout",
+			data:        `{"1": {"relay": 120, "claim": -30}}`,
+			wantVersion: 0,
+			wantRelay:   120,
+		},
+		{
+			name:        "version 1 without schemaVersion",
+			data:        `{"repetitions": 1, "warmup": 0, "cases": [{"nestedMessages": 1, "samples": [{"relay": 120, "claim": -30}], "failures": []}]}`,
+			wantVersion: 1,
+			wantRelay:   120,
+		},
+		{
+			name:        "current schema version",
+			data:        `{"schemaVersion": 2, "repetitions": 1, "cases": [{"nestedMessages": 1, "samples": [{"relay": {"gasDelta": 120}, "claim": {"gasDelta": -30}}]}]}`,
+			wantVersion: gasAnalysisSchemaVersion,
+			wantRelay:   120,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "gas_analysis.json")
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+			results, err := loadGasAnalysisResults(path)
+			if err != nil {
+				t.Fatalf("loadGasAnalysisResults: %v", err)
+			}
+			if results.SchemaVersion != tt.wantVersion {
+				t.Errorf("schema version = %d, want %d", results.SchemaVersion, tt.wantVersion)
+			}
+			if len(results.Cases) != 1 || len(results.Cases[0].Samples) != 1 {
+				t.Fatalf("got %d cases, want 1 case with 1 sample", len(results.Cases))
+			}
+			if delta := results.Cases[0].Samples[0].Relay.GasDelta; delta == nil || delta.Int64() != tt.wantRelay {
+				t.Errorf("relay gas delta = %v, want %d", delta, tt.wantRelay)
+			}
+			if results.Cases[0].Stats == nil {
+				t.Error("stats were not computed")
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+// This file contains the output formats of the gas analysis results and the export command converting results files.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Output formats of the gas analysis results
+const (
+	// formatJSON is the versioned layout described by results/gas_analysis.schema.json
+	formatJSON = "json"
+	// formatCSV has a row per sample with the cost breakdown of both transactions, for spreadsheets
+	formatCSV = "csv"
+	// formatMarkdown is the statistics table of every nested message count, for PR descriptions
+	formatMarkdown = "markdown"
+)
+
+// resultsFormatExtensions maps the output formats to their file extension
+var resultsFormatExtensions = map[string]string{
+	formatJSON:     ".json",
+	formatCSV:      ".csv",
+	formatMarkdown: ".md",
+}
+
+// parseOutputFormats parses a comma separated list of output formats, e.g. "json,csv"
+func parseOutputFormats(s string) ([]string, error) {
+	var formats []string
+	seen := make(map[string]bool)
+	for _, format := range strings.Split(s, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "md" {
+			format = formatMarkdown
+		}
+		if _, ok := resultsFormatExtensions[format]; !ok {
+			return nil, fmt.Errorf("unknown output format %q, expected %s, %s or %s", format, formatJSON, formatCSV, formatMarkdown)
+		}
+		if !seen[format] {
+			seen[format] = true
+			formats = append(formats, format)
+		}
+	}
+	return formats, nil
+}
+
+// encodeGasAnalysisResults writes the results in the given output format
+func encodeGasAnalysisResults(w io.Writer, results *gasAnalysisResults, format string) error {
+	switch format {
+	case formatJSON:
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	case formatCSV:
+		return writeGasAnalysisCSV(w, results)
+	case formatMarkdown:
+		return writeGasAnalysisMarkdown(w, results)
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+// costColumns are the CSV columns of a TxCostBreakdown, prefixed by the transaction kind
+var costColumns = []string{"txHash", "gasUsed", "calculatedGas", "gasDelta", "baseFee", "actualCost", "eventCost", "l1Fee", "profit", "calldataSize"}
+
+// writeGasAnalysisCSV writes a header and a row per sample. The cost columns of samples read from files older than
+// gasAnalysisSchemaVersion are empty, except for the gas deltas.
+func writeGasAnalysisCSV(w io.Writer, results *gasAnalysisResults) error {
+	cw := csv.NewWriter(w)
+	header := []string{"nestedMessages", "sample", "messageHash"}
+	for _, kind := range []string{"relay", "claim"} {
+		for _, column := range costColumns {
+			header = append(header, kind+strings.ToUpper(column[:1])+column[1:])
+		}
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, result := range results.Cases {
+		for i, sample := range result.Samples {
+			row := []string{strconv.Itoa(result.NestedMessages), strconv.Itoa(i + 1), hashCell(sample.MessageHash)}
+			row = append(row, sample.Relay.csvCells()...)
+			row = append(row, sample.Claim.csvCells()...)
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvCells formats the breakdown in the order of costColumns
+func (b *TxCostBreakdown) csvCells() []string {
+	gasUsed, calldataSize := "", ""
+	if b.TxHash != (common.Hash{}) {
+		gasUsed, calldataSize = strconv.FormatUint(b.GasUsed, 10), strconv.Itoa(b.CalldataSize)
+	}
+	return []string{
+		hashCell(b.TxHash),
+		gasUsed,
+		bigIntCell(b.CalculatedGas),
+		bigIntCell(b.GasDelta),
+		bigIntCell(b.BaseFee),
+		bigIntCell(b.ActualCost),
+		bigIntCell(b.EventCost),
+		bigIntCell(b.L1Fee),
+		bigIntCell(b.Profit),
+		calldataSize,
+	}
+}
+
+// hashCell formats a hash, empty when unknown
+func hashCell(h common.Hash) string {
+	if h == (common.Hash{}) {
+		return ""
+	}
+	return h.Hex()
+}
+
+// bigIntCell formats an amount, empty when unknown
+func bigIntCell(v *big.Int) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+// writeGasAnalysisMarkdown writes the statistics of every nested message count as a Markdown table
+func writeGasAnalysisMarkdown(w io.Writer, results *gasAnalysisResults) error {
+	var b strings.Builder
+	b.WriteString("### Gas analysis\n\n")
+	if results.SchemaVersion == gasAnalysisSchemaVersion {
+		fmt.Fprintf(&b, "Chain %d → %d, %s, %d recorded and %d warm-up runs per nested message count. ",
+			results.Origin, results.Destination, results.CreatedAt.Format("2006-01-02 15:04 MST"), results.Repetitions, results.Warmup)
+	}
+	b.WriteString("Gas deltas are the gas the GasTank paid for minus the gas used, negative when under-reimbursed.\n\n")
+	b.WriteString("| nested | runs | failed attempts | relay mean | median | min | max | stddev | p95 | claim mean | median | min | max | stddev | p95 |\n")
+	b.WriteString("|" + strings.Repeat("---:|", 15) + "\n")
+	for _, result := range results.Cases {
+		if result.Stats == nil {
+			fmt.Fprintf(&b, "| %d | 0 | %d |%s\n", result.NestedMessages, len(result.Failures), strings.Repeat(" - |", 12))
+			continue
+		}
+		relay := strings.ReplaceAll(result.Stats.Relay.row(), "\t", " | ")
+		claim := strings.ReplaceAll(result.Stats.Claim.row(), "\t", " | ")
+		fmt.Fprintf(&b, "| %d | %d | %d | %s | %s |\n", result.NestedMessages, result.Stats.Relay.Count, len(result.Failures), relay, claim)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeResultsFile writes the results in the given output format to path
+func writeResultsFile(path string, results *gasAnalysisResults, format string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+	if err := encodeGasAnalysisResults(f, results, format); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// runExport converts results files to the given output formats, writing every converted file to outDir, or next
+// to its source when outDir is empty. Without files, every results file of the results directory is converted.
+func runExport(files, formats []string, outDir string) error {
+	if len(files) == 0 {
+		var err error
+		if files, err = findGasAnalysisFiles(resultsDir()); err != nil {
+			return err
+		}
+	}
+	if outDir != "" {
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	for _, file := range files {
+		results, err := loadGasAnalysisResults(file)
+		if err != nil {
+			return err
+		}
+		dir := outDir
+		if dir == "" {
+			dir = filepath.Dir(file)
+		}
+		base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		for _, format := range formats {
+			path := filepath.Join(dir, base+resultsFormatExtensions[format])
+			// Never overwrite the source file
+			if sameFile(path, file) {
+				fmt.Printf("%s: skipping %s output, it would overwrite the source\n", file, format)
+				continue
+			}
+			// The samples of older files lack the fields the current layout requires
+			if format == formatJSON && results.SchemaVersion != gasAnalysisSchemaVersion {
+				fmt.Printf("%s: skipping %s output, the file predates schema version %d\n", file, format, gasAnalysisSchemaVersion)
+				continue
+			}
+			if err := writeResultsFile(path, results, format); err != nil {
+				return err
+			}
+			fmt.Printf("%s -> %s\n", file, path)
+		}
+	}
+	return nil
+}
+
+// sameFile reports whether two paths name the same file
+func sameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
@@ -0,0 +1,97 @@
+// This file contains the statistics gasanalysis computes over the samples of every nested message count.
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"text/tabwriter"
+)
+
+// sampleStats summarizes a series of gas deltas. Stddev is the population standard deviation and the median and
+// p95 interpolate linearly between samples, the way numpy computes them in plot_average_gas_analysis.py.
+type sampleStats struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Stddev float64 `json:"stddev"`
+	P95    float64 `json:"p95"`
+}
+
+// gasDeltaStats summarizes the relay and claim gas deltas of a nested message count
+type gasDeltaStats struct {
+	Relay sampleStats `json:"relay"`
+	Claim sampleStats `json:"claim"`
+}
+
+// computeStats summarizes the values, which must not be empty
+func computeStats(values []float64) sampleStats {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+	var squares float64
+	for _, v := range sorted {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return sampleStats{
+		Count:  len(sorted),
+		Mean:   mean,
+		Median: percentile(sorted, 50),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Stddev: math.Sqrt(squares / float64(len(sorted))),
+		P95:    percentile(sorted, 95),
+	}
+}
+
+// percentile returns the p-th percentile of sorted values, interpolating linearly between the closest ranks
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// computeCaseStats summarizes the samples of a nested message count, nil when every run failed
+func computeCaseStats(samples []*GasAnalysisSample) *gasDeltaStats {
+	if len(samples) == 0 {
+		return nil
+	}
+	relay := make([]float64, len(samples))
+	claim := make([]float64, len(samples))
+	for i, sample := range samples {
+		relay[i], _ = new(big.Float).SetInt(sample.Relay.GasDelta).Float64()
+		claim[i], _ = new(big.Float).SetInt(sample.Claim.GasDelta).Float64()
+	}
+	return &gasDeltaStats{Relay: computeStats(relay), Claim: computeStats(claim)}
+}
+
+// writeStatsTable prints the statistics of every nested message count as an aligned table. Runs counts the
+// samples, failed attempts every failed run including the retries of a repetition that succeeded later.
+func writeStatsTable(w io.Writer, cases []*gasAnalysisCase) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "nested\truns\tfailed attempts\t\trelay mean\tmedian\tmin\tmax\tstddev\tp95\t\tclaim mean\tmedian\tmin\tmax\tstddev\tp95\t")
+	for _, result := range cases {
+		if result.Stats == nil {
+			fmt.Fprintf(tw, "%d\t0\t%d\t\t-\t-\t-\t-\t-\t-\t\t-\t-\t-\t-\t-\t-\t\n", result.NestedMessages, len(result.Failures))
+			continue
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%d\t\t%s\t\t%s\t\n", result.NestedMessages, result.Stats.Relay.Count, len(result.Failures), result.Stats.Relay.row(), result.Stats.Claim.row())
+	}
+	return tw.Flush()
+}
+
+// row formats the statistics as tab separated table cells
+func (s sampleStats) row() string {
+	return fmt.Sprintf("%.1f\t%.1f\t%.0f\t%.0f\t%.1f\t%.1f", s.Mean, s.Median, s.Min, s.Max, s.Stddev, s.P95)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// The expected values match numpy's mean, median, min, max, std and percentile(95) of the same samples
+func TestComputeStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   sampleStats
+	}{
+		{
+			name:   "one sample",
+			values: []float64{-120},
+			want:   sampleStats{Count: 1, Mean: -120, Median: -120, Min: -120, Max: -120, Stddev: 0, P95: -120},
+		},
+		{
+			name:   "even count",
+			values: []float64{4, 1, 3, 2},
+			want:   sampleStats{Count: 4, Mean: 2.5, Median: 2.5, Min: 1, Max: 4, Stddev: math.Sqrt(1.25), P95: 3.85},
+		},
+		{
+			name:   "p95 between the two highest samples",
+			values: []float64{100, -50, 20, 0, 10},
+			want:   sampleStats{Count: 5, Mean: 16, Median: 10, Min: -50, Max: 100, Stddev: math.Sqrt(2344), P95: 84},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := slices.Clone(tt.values)
+			got := computeStats(values)
+			if !equalStats(got, tt.want) {
+				t.Errorf("computeStats = %+v, want %+v", got, tt.want)
+			}
+			if !slices.Equal(values, tt.values) {
+				t.Errorf("computeStats reordered its input to %v", values)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{10, 20, 30, 40}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 10},
+		{50, 25},
+		{95, 38.5},
+		{100, 40},
+	}
+	for _, tt := range tests {
+		if got := percentile(sorted, tt.p); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("percentile(%v, %v) = %v, want %v", sorted, tt.p, got, tt.want)
+		}
+	}
+}
+
+func equalStats(a, b sampleStats) bool {
+	near := func(x, y float64) bool { return math.Abs(x-y) <= 1e-9 }
+	return a.Count == b.Count && near(a.Mean, b.Mean) && near(a.Median, b.Median) && near(a.Min, b.Min) &&
+		near(a.Max, b.Max) && near(a.Stddev, b.Stddev) && near(a.P95, b.P95)
+}
@@ -0,0 +1,388 @@
+// This script manages the GasTank account of a gas provider: deposits, balances and the delayed withdrawals.
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// gasTankAccountCommands lists the gastank subcommands operating on an account, the others run the relay flow
+var gasTankAccountCommands = []string{"deposit", "balance", "withdraw init", "withdraw finalize", "withdrawal-status"}
+
+// gasTankAccountFlags holds the flags of the gastank account subcommands
+type gasTankAccountFlags struct {
+	chain   *uint64
+	account *string
+	amount  *string
+	to      *string
+}
+
+// gasTankAccount is an account operating on the GasTank of a single chain
+type gasTankAccount struct {
+	chain   *gasTankChain
+	address common.Address
+	// key is nil when the account was given as an address, which only allows reading its state
+	key *ecdsa.PrivateKey
+}
+
+// depositTarget is the GasTank balance a top-up aims for, either a fraction of MAX_DEPOSIT or an explicit amount
+type depositTarget struct {
+	fraction *big.Rat
+	amount   *big.Int
+}
+
+// parseDepositTarget parses a percentage of MAX_DEPOSIT such as "50%" or an ETH amount such as "0.005"
+func parseDepositTarget(target string) (depositTarget, error) {
+	target = strings.TrimSpace(target)
+	if percent, ok := strings.CutSuffix(target, "%"); ok {
+		fraction, ok := new(big.Rat).SetString(percent)
+		if !ok || fraction.Sign() <= 0 || fraction.Cmp(big.NewRat(100, 1)) > 0 {
+			return depositTarget{}, fmt.Errorf("%q is not a percentage between 0 and 100", target)
+		}
+		return depositTarget{fraction: fraction.Quo(fraction, big.NewRat(100, 1))}, nil
+	}
+	amount, err := parseEther(target)
+	if err != nil {
+		return depositTarget{}, err
+	}
+	return depositTarget{amount: amount}, nil
+}
+
+// balance resolves the target against MAX_DEPOSIT and reports whether it had to be capped
+func (t depositTarget) balance(maxDeposit *big.Int) (*big.Int, bool) {
+	if t.amount == nil {
+		scaled := new(big.Rat).Mul(t.fraction, new(big.Rat).SetInt(maxDeposit))
+		return new(big.Int).Quo(scaled.Num(), scaled.Denom()), false
+	}
+	if t.amount.Cmp(maxDeposit) > 0 {
+		return new(big.Int).Set(maxDeposit), true
+	}
+	return new(big.Int).Set(t.amount), false
+}
+
+// withdrawalStatus is the state of an account's pending withdrawal
+type withdrawalStatus struct {
+	Amount      *big.Int
+	InitiatedAt time.Time
+	UnlocksAt   time.Time
+	// Remaining is the time left on WITHDRAWAL_DELAY, measured against the latest block timestamp
+	Remaining time.Duration
+}
+
+// parseGasTankAccountCommand splits the account subcommand, which may span two arguments, from its flags
+func parseGasTankAccountCommand(args []string) (string, []string, bool) {
+	for _, command := range gasTankAccountCommands {
+		words := strings.Fields(command)
+		if len(args) < len(words) {
+			continue
+		}
+		if strings.Join(args[:len(words)], " ") == command {
+			return command, args[len(words):], true
+		}
+	}
+	return "", nil, false
+}
+
+// runGasTankAccountCommand runs one of gasTankAccountCommands on the configured chain and account
+func runGasTankAccountCommand(cfg *Config, command string, flags *gasTankAccountFlags) error {
+	account, err := openGasTankAccount(cfg, *flags.chain, *flags.account)
+	if err != nil {
+		return err
+	}
+
+	var amount *big.Int
+	if *flags.amount != "" {
+		amount, err = parseEther(*flags.amount)
+		if err != nil {
+			return fmt.Errorf("invalid amount: %w", err)
+		}
+	}
+	to := account.address
+	if *flags.to != "" {
+		if !common.IsHexAddress(*flags.to) {
+			return fmt.Errorf("invalid recipient %q", *flags.to)
+		}
+		to = common.HexToAddress(*flags.to)
+	}
+
+	switch command {
+	case "deposit":
+		if amount == nil {
+			return fmt.Errorf("deposit requires --amount")
+		}
+		return account.deposit(amount, to)
+	case "balance":
+		return account.printBalance()
+	case "withdraw init":
+		return account.initiateWithdrawal(amount)
+	case "withdraw finalize":
+		return account.finalizeWithdrawal(to)
+	case "withdrawal-status":
+		return account.printWithdrawalStatus()
+	}
+	return fmt.Errorf("unknown gastank command %q", command)
+}
+
+// openGasTankAccount connects to the GasTank of the given chain, the origin when chainID is 0. The account is
+// "gasProvider", "relayer", a hex private key or, for the read-only commands, an address.
+func openGasTankAccount(cfg *Config, chainID uint64, account string) (*gasTankAccount, error) {
+	if chainID == 0 {
+		chainID = cfg.Origin.ChainID
+	}
+	if _, err := cfg.Chain(chainID); err != nil {
+		return nil, err
+	}
+	contracts, err := loadSupersimContracts(cfg.ContractsFile)
+	if err != nil {
+		return nil, err
+	}
+	chains, err := dialGasTankChains(cfg, contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &gasTankAccount{chain: chains[chainID]}
+	switch {
+	case account == "" || account == "gasProvider":
+		a.key, err = cfg.GasProvider()
+	case account == "relayer":
+		a.key, err = cfg.Relayer()
+	case common.IsHexAddress(account):
+		a.address = common.HexToAddress(account)
+		return a, nil
+	default:
+		a.key, err = parsePrivateKey(account)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account %q: %w", account, err)
+	}
+	a.address = crypto.PubkeyToAddress(*a.key.Public().(*ecdsa.PublicKey))
+	return a, nil
+}
+
+// signer returns the account's private key, failing for accounts given as an address
+func (a *gasTankAccount) signer() (*ecdsa.PrivateKey, error) {
+	if a.key == nil {
+		return nil, fmt.Errorf("account %s was given as an address, a private key is needed to send transactions", a.address.Hex())
+	}
+	return a.key, nil
+}
+
+// deposit deposits amount into the GasTank balance of to
+func (a *gasTankAccount) deposit(amount *big.Int, to common.Address) error {
+	key, err := a.signer()
+	if err != nil {
+		return err
+	}
+	maxDeposit, err := a.maxDeposit()
+	if err != nil {
+		return err
+	}
+	balance, err := getCurrentGasProviderBalance(a.chain.client, to, a.chain.gasTank)
+	if err != nil {
+		return fmt.Errorf("failed to get current balance: %w", err)
+	}
+	if room := new(big.Int).Sub(maxDeposit, balance); amount.Cmp(room) > 0 {
+		return fmt.Errorf("depositing %s ETH would exceed MAX_DEPOSIT of %s ETH, at most %s ETH can be added to the balance of %s",
+			formatEther(amount), formatEther(maxDeposit), formatEther(room), to.Hex())
+	}
+
+	fmt.Printf("Depositing %s ETH for %s into GasTank %s on chain %d...\n", formatEther(amount), to.Hex(), a.chain.gasTank.Hex(), a.chain.ChainID)
+	depositTx, err := a.send(key, amount, gasTankContract.PackDeposit(to))
+	if err != nil {
+		return fmt.Errorf("deposit transaction failed: %w", err)
+	}
+	fmt.Printf("Deposit transaction successful: %s\n", depositTx.TxHash.Hex())
+
+	balance, err = getCurrentGasProviderBalance(a.chain.client, to, a.chain.gasTank)
+	if err != nil {
+		return fmt.Errorf("failed to get current balance: %w", err)
+	}
+	fmt.Printf("New balance of %s: %s ETH\n", to.Hex(), formatEther(balance))
+	return nil
+}
+
+// topUp deposits whatever the account's balance lacks to reach target, capped at MAX_DEPOSIT
+func (a *gasTankAccount) topUp(target depositTarget, verbose bool) error {
+	key, err := a.signer()
+	if err != nil {
+		return err
+	}
+	maxDeposit, err := a.maxDeposit()
+	if err != nil {
+		return err
+	}
+	targetBalance, capped := target.balance(maxDeposit)
+	if capped {
+		log.Printf("Warning: deposit target capped at MAX_DEPOSIT of %s ETH", formatEther(maxDeposit))
+	}
+
+	currentBalance, err := getCurrentGasProviderBalance(a.chain.client, a.address, a.chain.gasTank)
+	if err != nil {
+		return fmt.Errorf("failed to get current balance: %w", err)
+	}
+	logfIf(verbose, "Current balance is %s ETH, target is %s ETH (MAX_DEPOSIT is %s ETH)\n", formatEther(currentBalance), formatEther(targetBalance), formatEther(maxDeposit))
+	if currentBalance.Cmp(targetBalance) >= 0 {
+		logIf(verbose, "Balance is sufficient, no deposit needed.")
+		return nil
+	}
+
+	amountToDeposit := new(big.Int).Sub(targetBalance, currentBalance)
+	logfIf(verbose, "Depositing %s ETH to reach the target balance...\n", formatEther(amountToDeposit))
+	depositTx, err := a.send(key, amountToDeposit, gasTankContract.PackDeposit(a.address))
+	if err != nil {
+		return fmt.Errorf("deposit transaction failed: %w", err)
+	}
+	logfIf(verbose, "Deposit transaction successful: %s\n", depositTx.TxHash.Hex())
+	return nil
+}
+
+// send sends a transaction to the GasTank from the account
+func (a *gasTankAccount) send(key *ecdsa.PrivateKey, value *big.Int, data []byte) (*types.Receipt, error) {
+	sender, err := a.chain.sender(key)
+	if err != nil {
+		return nil, err
+	}
+	return sender.Send(context.Background(), &a.chain.gasTank, value, data)
+}
+
+// maxDeposit reads MAX_DEPOSIT, the highest balance the GasTank accepts per gas provider
+func (a *gasTankAccount) maxDeposit() (*big.Int, error) {
+	maxDeposit, err := callView(context.Background(), a.chain.client, a.chain.gasTank, gasTankContract.PackMAXDEPOSIT(), gasTankContract.UnpackMAXDEPOSIT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MAX_DEPOSIT: %w", err)
+	}
+	return maxDeposit, nil
+}
+
+// printBalance prints the account's GasTank balance
+func (a *gasTankAccount) printBalance() error {
+	balance, err := getCurrentGasProviderBalance(a.chain.client, a.address, a.chain.gasTank)
+	if err != nil {
+		return fmt.Errorf("failed to get current balance: %w", err)
+	}
+	fmt.Printf("GasTank balance of %s on chain %d: %s ETH\n", a.address.Hex(), a.chain.ChainID, formatEther(balance))
+	return nil
+}
+
+// initiateWithdrawal starts the withdrawal delay for amount, the whole balance when amount is nil
+func (a *gasTankAccount) initiateWithdrawal(amount *big.Int) error {
+	key, err := a.signer()
+	if err != nil {
+		return err
+	}
+	if amount == nil {
+		amount, err = getCurrentGasProviderBalance(a.chain.client, a.address, a.chain.gasTank)
+		if err != nil {
+			return fmt.Errorf("failed to get current balance: %w", err)
+		}
+	}
+
+	fmt.Printf("Initiating the withdrawal of %s ETH from GasTank %s on chain %d...\n", formatEther(amount), a.chain.gasTank.Hex(), a.chain.ChainID)
+	withdrawTx, err := a.send(key, big.NewInt(0), gasTankContract.PackInitiateWithdrawal(amount))
+	if err != nil {
+		return fmt.Errorf("initiate withdrawal transaction failed: %w", err)
+	}
+	fmt.Printf("Initiate withdrawal transaction successful: %s\n", withdrawTx.TxHash.Hex())
+	return a.printWithdrawalStatus()
+}
+
+// finalizeWithdrawal sends the pending withdrawal to the recipient once the delay has elapsed
+func (a *gasTankAccount) finalizeWithdrawal(to common.Address) error {
+	key, err := a.signer()
+	if err != nil {
+		return err
+	}
+	status, err := a.withdrawalStatus()
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		return fmt.Errorf("%s has no pending withdrawal on chain %d", a.address.Hex(), a.chain.ChainID)
+	}
+	if status.Remaining > 0 {
+		return fmt.Errorf("withdrawal is still pending for %s, until %s", formatDelay(status.Remaining), status.UnlocksAt.UTC().Format(time.RFC3339))
+	}
+
+	fmt.Printf("Finalizing the withdrawal of %s ETH to %s on chain %d...\n", formatEther(status.Amount), to.Hex(), a.chain.ChainID)
+	finalizeTx, err := a.send(key, big.NewInt(0), gasTankContract.PackFinalizeWithdrawal(to))
+	if err != nil {
+		return fmt.Errorf("finalize withdrawal transaction failed: %w", err)
+	}
+	fmt.Printf("Finalize withdrawal transaction successful: %s\n", finalizeTx.TxHash.Hex())
+	return a.printBalance()
+}
+
+// withdrawalStatus reads the account's pending withdrawal, nil when there is none
+func (a *gasTankAccount) withdrawalStatus() (*withdrawalStatus, error) {
+	ctx := context.Background()
+	withdrawal, err := callView(ctx, a.chain.client, a.chain.gasTank, gasTankContract.PackWithdrawals(a.address), gasTankContract.UnpackWithdrawals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get withdrawal: %w", err)
+	}
+	if withdrawal.Timestamp.Sign() == 0 {
+		return nil, nil
+	}
+	delay, err := callView(ctx, a.chain.client, a.chain.gasTank, gasTankContract.PackWITHDRAWALDELAY(), gasTankContract.UnpackWITHDRAWALDELAY)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get WITHDRAWAL_DELAY: %w", err)
+	}
+	head, err := a.chain.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	initiatedAt := time.Unix(withdrawal.Timestamp.Int64(), 0)
+	unlocksAt := initiatedAt.Add(time.Duration(delay.Int64()) * time.Second)
+	remaining := max(unlocksAt.Sub(time.Unix(int64(head.Time), 0)), 0)
+	return &withdrawalStatus{
+		Amount:      withdrawal.Amount,
+		InitiatedAt: initiatedAt,
+		UnlocksAt:   unlocksAt,
+		Remaining:   remaining,
+	}, nil
+}
+
+// printWithdrawalStatus prints the account's pending withdrawal and the time left before it can be finalized
+func (a *gasTankAccount) printWithdrawalStatus() error {
+	status, err := a.withdrawalStatus()
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		fmt.Printf("%s has no pending withdrawal on chain %d\n", a.address.Hex(), a.chain.ChainID)
+		return nil
+	}
+	fmt.Printf("Pending withdrawal of %s on chain %d:\n", a.address.Hex(), a.chain.ChainID)
+	fmt.Printf("  - Amount:    %s ETH\n", formatEther(status.Amount))
+	fmt.Printf("  - Initiated: %s\n", status.InitiatedAt.UTC().Format(time.RFC3339))
+	fmt.Printf("  - Unlocks:   %s\n", status.UnlocksAt.UTC().Format(time.RFC3339))
+	if status.Remaining > 0 {
+		fmt.Printf("  - Remaining: %s\n", formatDelay(status.Remaining))
+	} else {
+		fmt.Println("  - Ready to finalize")
+	}
+	return nil
+}
+
+// formatDelay formats a duration in days, hours, minutes and seconds
+func formatDelay(d time.Duration) string {
+	d = d.Round(time.Second)
+	days, rest := d/(24*time.Hour), d%(24*time.Hour)
+	switch {
+	case days == 0:
+		return rest.String()
+	case rest == 0:
+		return fmt.Sprintf("%dd", days)
+	}
+	return fmt.Sprintf("%dd %s", days, rest)
+}
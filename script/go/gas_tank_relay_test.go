@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+
+	"supersim-e2e-example/interop"
+)
+
+// TestGasTankRelay sends a message producing nested messages, relays and claims it and its nested messages
+// through the GasTank on the simulated chains
+func TestGasTankRelay(t *testing.T) {
+	tests := []struct {
+		name string
+		// minerMinTip is the tip the chains mine transactions from, the harness transactions pay 1 wei
+		minerMinTip   int64
+		resubmitAfter time.Duration
+		// wantReplaced expects the relay and the claim to be mined as replacements with a bumped tip
+		wantReplaced bool
+	}{
+		{
+			name:        "mined as sent",
+			minerMinTip: 1,
+		},
+		{
+			// The txpool accepts the 1 wei tip but the chains do not mine it, every transaction stays pending
+			// until the TxSender replaces it with a tip bumped to 2 wei
+			name:          "replaced after the resubmit delay",
+			minerMinTip:   2,
+			resubmitAfter: 500 * time.Millisecond,
+			wantReplaced:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHarness(t, simulated.WithMinerMinTip(big.NewInt(tt.minerMinTip)))
+			h.cfg.Tx.ResubmitAfter = tt.resubmitAfter
+			testGasTankRelay(t, h, tt.wantReplaced)
+		})
+	}
+}
+
+func testGasTankRelay(t *testing.T, h *testHarness, wantReplaced bool) {
+	ctx := context.Background()
+
+	const nested = 2
+	target, err := parseDepositTarget("100%")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sample, err := gasTankRelay(h.cfg, nested, target, true, false)
+	if err != nil {
+		t.Fatalf("gasTankRelay: %v", err)
+	}
+	if sample.Relay.GasUsed == 0 || sample.Claim.GasUsed == 0 {
+		t.Fatalf("sample = %+v, want the gas used by the relay and the claim", sample)
+	}
+
+	receipt, err := h.destination.Client().TransactionReceipt(ctx, sample.Relay.TxHash)
+	if err != nil {
+		t.Fatalf("failed to get relay receipt: %v", err)
+	}
+	receiptLog := findLog(receipt.Logs, harnessGasTankAddr, interop.RelayedMessageGasReceiptTopic)
+	if receiptLog == nil {
+		t.Fatal("relay emitted no RelayedMessageGasReceipt")
+	}
+	gasReceipt, err := interop.GasReceiptFromLog(receiptLog)
+	if err != nil {
+		t.Fatalf("GasReceiptFromLog: %v", err)
+	}
+	// sample.MessageHash is hashed in Go from the SentMessage log, the receipt carries the hash GasTank computed
+	if gasReceipt.MessageHash != sample.MessageHash || len(gasReceipt.NestedMessageHashes) != nested {
+		t.Fatalf("gas receipt = %+v, want message %s with %d nested messages", gasReceipt, sample.MessageHash.Hex(), nested)
+	}
+	var nestedHashes []common.Hash
+	for _, sentLog := range receipt.Logs {
+		if sentLog.Address != l2CrossDomainMessengerAddr || len(sentLog.Topics) == 0 || sentLog.Topics[0] != interop.SentMessageTopic {
+			continue
+		}
+		hash, err := sentMessageHash(h.cfg.Destination.ID(), sentLog)
+		if err != nil {
+			t.Fatalf("failed to hash nested message: %v", err)
+		}
+		nestedHashes = append(nestedHashes, hash)
+	}
+	if !slices.Equal(nestedHashes, gasReceipt.NestedMessageHashes) {
+		t.Errorf("nested message hashes = %v, GasTank reported %v", nestedHashes, gasReceipt.NestedMessageHashes)
+	}
+
+	if wantReplaced {
+		for _, tx := range []struct {
+			kind   string
+			client simulated.Client
+			hash   common.Hash
+		}{
+			{"relay", h.destination.Client(), sample.Relay.TxHash},
+			{"claim", h.origin.Client(), sample.Claim.TxHash},
+		} {
+			mined, _, err := tx.client.TransactionByHash(ctx, tx.hash)
+			if err != nil {
+				t.Fatalf("failed to get %s transaction: %v", tx.kind, err)
+			}
+			if mined.GasTipCap().Cmp(big.NewInt(int64(h.cfg.Tx.TipWei))) <= 0 {
+				t.Errorf("%s transaction %s was mined with the original tip %s, want a replacement", tx.kind, tx.hash.Hex(), mined.GasTipCap())
+			}
+		}
+	}
+
+	// The claim of the message authorizes its nested messages on the origin, where they are claimed as well
+	for _, hash := range append(gasReceipt.NestedMessageHashes, sample.MessageHash) {
+		claimed, err := callView(ctx, h.origin.Client(), harnessGasTankAddr, gasTankContract.PackClaimed(hash), gasTankContract.UnpackClaimed)
+		if err != nil {
+			t.Fatalf("failed to call claimed: %v", err)
+		}
+		if !claimed {
+			t.Errorf("message %s was not claimed", hash.Hex())
+		}
+	}
+}
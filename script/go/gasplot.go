@@ -0,0 +1,321 @@
+// This script charts the gas analysis results without Python: it aggregates every sample of the
+// results/gas_analysis_*.json files per nested message count, as plot_average_gas_analysis.py does,
+// and writes an SVG chart and an HTML report.
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Target range of the gas deltas, drawn as a band on the chart
+const (
+	gasDeltaTargetMin = 0
+	gasDeltaTargetMax = 15
+)
+
+// Layout of the chart, in pixels
+const (
+	chartWidth       = 960
+	chartPanelHeight = 420
+	chartMarginLeft  = 90
+	chartMarginRight = 30
+	chartMarginTop   = 50
+	chartMarginBot   = 60
+)
+
+// gasPlotPoint summarizes every sample of a nested message count across the results files
+type gasPlotPoint struct {
+	NestedMessages int
+	Relay          sampleStats
+	Claim          sampleStats
+}
+
+// findGasAnalysisFiles returns the results files of a directory
+func findGasAnalysisFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "gas_analysis_*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no gas_analysis JSON files found in %s", dir)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// aggregateGasAnalysisFiles pools the relay and claim gas deltas of every file per nested message count
+func aggregateGasAnalysisFiles(files []string) ([]gasPlotPoint, int, error) {
+	relay := make(map[int][]float64)
+	claim := make(map[int][]float64)
+	samples := 0
+	for _, file := range files {
+		results, err := loadGasAnalysisResults(file)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, c := range results.Cases {
+			for _, sample := range c.Samples {
+				relayDelta, _ := new(big.Float).SetInt(sample.Relay.GasDelta).Float64()
+				claimDelta, _ := new(big.Float).SetInt(sample.Claim.GasDelta).Float64()
+				relay[c.NestedMessages] = append(relay[c.NestedMessages], relayDelta)
+				claim[c.NestedMessages] = append(claim[c.NestedMessages], claimDelta)
+				samples++
+			}
+		}
+	}
+	if samples == 0 {
+		return nil, 0, fmt.Errorf("no gas analysis samples found")
+	}
+
+	points := make([]gasPlotPoint, 0, len(relay))
+	for nested := range relay {
+		points = append(points, gasPlotPoint{NestedMessages: nested, Relay: computeStats(relay[nested]), Claim: computeStats(claim[nested])})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].NestedMessages < points[j].NestedMessages })
+	return points, samples, nil
+}
+
+// chartPanel is one of the stacked plots of the chart
+type chartPanel struct {
+	title string
+	label string
+	color string
+	// square draws square markers instead of circles
+	square bool
+	// padding is added below and above the error bars, in gas units
+	padding float64
+	stats   func(gasPlotPoint) sampleStats
+}
+
+var gasChartPanels = []chartPanel{
+	{title: "Average Relay Gas Delta vs Nested Messages", label: "Relay Delta (Mean ± StdDev)", color: "#2E86AB", padding: 10, stats: func(p gasPlotPoint) sampleStats { return p.Relay }},
+	{title: "Average Claim Gas Delta vs Nested Messages", label: "Claim Delta (Mean ± StdDev)", color: "#A23B72", square: true, padding: 50, stats: func(p gasPlotPoint) sampleStats { return p.Claim }},
+}
+
+// renderGasChart draws the mean and standard deviation of the relay and claim gas deltas as an SVG document
+func renderGasChart(points []gasPlotPoint) string {
+	var b strings.Builder
+	height := chartPanelHeight * len(gasChartPanels)
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n", chartWidth, height, chartWidth, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="white"/>`+"\n", chartWidth, height)
+	for i, panel := range gasChartPanels {
+		renderChartPanel(&b, panel, points, i, float64(i*chartPanelHeight))
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+func renderChartPanel(b *strings.Builder, panel chartPanel, points []gasPlotPoint, index int, top float64) {
+	left, right := float64(chartMarginLeft), float64(chartWidth-chartMarginRight)
+	plotTop, plotBottom := top+chartMarginTop, top+chartPanelHeight-chartMarginBot
+
+	// Axis ranges, matching the limits plot_average_gas_analysis.py sets
+	xMin, xMax := float64(points[0].NestedMessages), float64(points[len(points)-1].NestedMessages)
+	xPad := math.Max((xMax-xMin)*0.05, 1)
+	xMin, xMax = xMin-xPad, xMax+xPad
+	yMin, yMax := math.Inf(1), math.Inf(-1)
+	maxStddev := 0.0
+	for _, p := range points {
+		s := panel.stats(p)
+		yMin, yMax = math.Min(yMin, s.Mean), math.Max(yMax, s.Mean)
+		maxStddev = math.Max(maxStddev, s.Stddev)
+	}
+	yMin, yMax = yMin-maxStddev-panel.padding, yMax+maxStddev+panel.padding
+
+	x := func(v float64) float64 { return left + (v-xMin)/(xMax-xMin)*(right-left) }
+	y := func(v float64) float64 { return plotBottom - (v-yMin)/(yMax-yMin)*(plotBottom-plotTop) }
+
+	clipID := fmt.Sprintf("plot%d", index)
+	fmt.Fprintf(b, `<clipPath id="%s"><rect x="%.1f" y="%.1f" width="%.1f" height="%.1f"/></clipPath>`+"\n", clipID, left, plotTop, right-left, plotBottom-plotTop)
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f" text-anchor="middle" font-size="15">%s</text>`+"\n", (left+right)/2, top+30, template.HTMLEscapeString(panel.title))
+
+	// Grid and tick labels
+	for _, tick := range niceTicks(yMin, yMax, 8) {
+		fmt.Fprintf(b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#ddd"/>`+"\n", left, y(tick), right, y(tick))
+		fmt.Fprintf(b, `<text x="%.1f" y="%.1f" text-anchor="end">%s</text>`+"\n", left-6, y(tick)+4, formatTick(tick))
+	}
+	for _, tick := range niceTicks(xMin, xMax, 10) {
+		fmt.Fprintf(b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#ddd"/>`+"\n", x(tick), plotTop, x(tick), plotBottom)
+		fmt.Fprintf(b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`+"\n", x(tick), plotBottom+16, formatTick(tick))
+	}
+	fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="none" stroke="#333"/>`+"\n", left, plotTop, right-left, plotBottom-plotTop)
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f" text-anchor="middle">Number of Nested Messages</text>`+"\n", (left+right)/2, plotBottom+40)
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f" text-anchor="middle" transform="rotate(-90 %.1f %.1f)">Gas Delta (units)</text>`+"\n", left-60, (plotTop+plotBottom)/2, left-60, (plotTop+plotBottom)/2)
+
+	fmt.Fprintf(b, `<g clip-path="url(#%s)">`+"\n", clipID)
+	// Target band
+	fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="green" fill-opacity="0.3"/>`+"\n", left, y(gasDeltaTargetMax), right-left, y(gasDeltaTargetMin)-y(gasDeltaTargetMax))
+
+	// Mean line, error bars, markers and value annotations
+	var line []string
+	for _, p := range points {
+		line = append(line, fmt.Sprintf("%.1f,%.1f", x(float64(p.NestedMessages)), y(panel.stats(p).Mean)))
+	}
+	fmt.Fprintf(b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n", strings.Join(line, " "), panel.color)
+	for _, p := range points {
+		s := panel.stats(p)
+		px, py := x(float64(p.NestedMessages)), y(s.Mean)
+		low, high := y(s.Mean-s.Stddev), y(s.Mean+s.Stddev)
+		fmt.Fprintf(b, `<path d="M%.1f %.1fV%.1fM%.1f %.1fh10M%.1f %.1fh10" stroke="%s" stroke-width="1.5"/>`+"\n", px, low, high, px-5, low, px-5, high, panel.color)
+		if panel.square {
+			fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="8" height="8" fill="%s"/>`+"\n", px-4, py-4, panel.color)
+		} else {
+			fmt.Fprintf(b, `<circle cx="%.1f" cy="%.1f" r="4.5" fill="%s"/>`+"\n", px, py, panel.color)
+		}
+		fmt.Fprintf(b, `<text x="%.1f" y="%.1f" text-anchor="middle" font-size="10">%.0f±%.0f</text>`+"\n", px, py-10, s.Mean, s.Stddev)
+	}
+	b.WriteString("</g>\n")
+
+	// Legend
+	legendX, legendY := right-220, plotTop+10
+	fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="210" height="46" fill="white" fill-opacity="0.8" stroke="#ccc"/>`+"\n", legendX, legendY)
+	fmt.Fprintf(b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="2"/>`+"\n", legendX+8, legendY+14, legendX+30, legendY+14, panel.color)
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f">%s</text>`+"\n", legendX+36, legendY+18, template.HTMLEscapeString(panel.label))
+	fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="22" height="10" fill="green" fill-opacity="0.3"/>`+"\n", legendX+8, legendY+27)
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f">Target Range (%d-%d)</text>`+"\n", legendX+36, legendY+36, gasDeltaTargetMin, gasDeltaTargetMax)
+}
+
+// niceTicks returns about count round tick values covering [min, max]
+func niceTicks(min, max float64, count int) []float64 {
+	span := max - min
+	if span <= 0 {
+		return []float64{min}
+	}
+	raw := span / float64(count)
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := magnitude * 10
+	for _, factor := range []float64{1, 2, 5, 10} {
+		if raw <= factor*magnitude {
+			step = factor * magnitude
+			break
+		}
+	}
+	var ticks []float64
+	for tick := math.Ceil(min/step) * step; tick <= max; tick += step {
+		ticks = append(ticks, tick)
+	}
+	return ticks
+}
+
+func formatTick(v float64) string {
+	if math.Abs(v-math.Round(v)) < 1e-9 {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%g", v)
+}
+
+var gasReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Gas Analysis Report</title>
+<style>
+  body { font-family: sans-serif; margin: 2em auto; max-width: 1000px; color: #222; }
+  table { border-collapse: collapse; margin-bottom: 2em; }
+  th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: right; }
+  th { background: #f4f4f4; }
+  .out { color: #b00; }
+</style>
+</head>
+<body>
+<h1>Gas Analysis Report</h1>
+<p>Generated {{.Generated}} from {{.Samples}} samples in {{len .Files}} results files. Gas deltas are the gas the GasTank paid for minus the gas used; the target range is {{.TargetMin}} to {{.TargetMax}} gas units.</p>
+{{.Chart}}
+{{range .Tables}}
+<h2>{{.Title}}</h2>
+<table>
+<tr><th>Nested messages</th><th>Samples</th><th>Mean</th><th>Median</th><th>Min</th><th>Max</th><th>Stddev</th><th>p95</th></tr>
+{{range .Rows}}<tr{{if .OutOfTarget}} class="out"{{end}}><td>{{.NestedMessages}}</td><td>{{.Stats.Count}}</td><td>{{printf "%.1f" .Stats.Mean}}</td><td>{{printf "%.1f" .Stats.Median}}</td><td>{{printf "%.0f" .Stats.Min}}</td><td>{{printf "%.0f" .Stats.Max}}</td><td>{{printf "%.1f" .Stats.Stddev}}</td><td>{{printf "%.1f" .Stats.P95}}</td></tr>
+{{end}}</table>
+{{end}}
+<h2>Results files</h2>
+<ul>
+{{range .Files}}<li>{{.}}</li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+type gasReportRow struct {
+	NestedMessages int
+	Stats          sampleStats
+	OutOfTarget    bool
+}
+
+type gasReportTable struct {
+	Title string
+	Rows  []gasReportRow
+}
+
+// renderGasReport writes the HTML report embedding the chart, with a table per transaction kind
+func renderGasReport(path string, points []gasPlotPoint, samples int, files []string, chart string) error {
+	var tables []gasReportTable
+	for _, panel := range gasChartPanels {
+		table := gasReportTable{Title: strings.TrimPrefix(panel.title, "Average ")}
+		for _, p := range points {
+			s := panel.stats(p)
+			table.Rows = append(table.Rows, gasReportRow{NestedMessages: p.NestedMessages, Stats: s, OutOfTarget: s.Mean < gasDeltaTargetMin || s.Mean > gasDeltaTargetMax})
+		}
+		tables = append(tables, table)
+	}
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = filepath.Base(file)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer f.Close()
+	err = gasReportTemplate.Execute(f, map[string]any{
+		"Generated": time.Now().UTC().Format(time.RFC1123),
+		"Samples":   samples,
+		"Files":     names,
+		"TargetMin": gasDeltaTargetMin,
+		"TargetMax": gasDeltaTargetMax,
+		// The chart is generated by renderGasChart, which escapes every text it draws
+		"Chart":  template.HTML(chart),
+		"Tables": tables,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return f.Close()
+}
+
+// runGasPlot charts every results file of dir and writes the chart and report next to them
+func runGasPlot(dir string) error {
+	files, err := findGasAnalysisFiles(dir)
+	if err != nil {
+		return err
+	}
+	points, samples, err := aggregateGasAnalysisFiles(files)
+	if err != nil {
+		return err
+	}
+
+	chart := renderGasChart(points)
+	chartPath := filepath.Join(dir, "gas_analysis_average_chart.svg")
+	if err := os.WriteFile(chartPath, []byte(chart), 0644); err != nil {
+		return fmt.Errorf("failed to write chart: %w", err)
+	}
+	reportPath := filepath.Join(dir, "gas_analysis_report.html")
+	if err := renderGasReport(reportPath, points, samples, files, chart); err != nil {
+		return err
+	}
+
+	fmt.Println("📊 Average Gas Analysis Charts Generated!")
+	fmt.Println("Files saved:")
+	fmt.Printf("  - %s\n", filepath.Base(chartPath))
+	fmt.Printf("  - %s\n", filepath.Base(reportPath))
+	return nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+
+	"supersim-e2e-example/mockadmin"
+)
+
+// Addresses the harness places GasTank and MessageSender at on every chain. The GasTank must have the same
+// address on both chains, as claim only accepts receipts emitted by itself.
+var (
+	harnessGasTankAddr       = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	harnessMessageSenderAddr = common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+)
+
+// harnessCommitInterval is the interval between two blocks of the simulated chains
+const harnessCommitInterval = 50 * time.Millisecond
+
+// harnessRequiredEnv makes the harness tests fail instead of skipping when the contracts have not been built, for
+// CI jobs that must run them
+const harnessRequiredEnv = "SUPERSIM_E2E"
+
+// testHarness runs an origin and a destination chain in process, with GasTank, MessageSender and stubs of the
+// messenger, CrossL2Inbox and GasPriceOracle predeploys, and a configuration pointing the scripts at them
+type testHarness struct {
+	cfg         *Config
+	origin      *simulated.Backend
+	destination *simulated.Backend
+}
+
+// newTestHarness starts the simulated chains and the mock admin RPC, stopped when the test ends. It skips the
+// test when the contracts have not been built, the harness needs the artifacts of `forge build` in out/, and
+// fails it instead when SUPERSIM_E2E=1. The options are applied to both chains after the harness defaults.
+func newTestHarness(t *testing.T, options ...func(*node.Config, *ethconfig.Config)) *testHarness {
+	t.Helper()
+	alloc := harnessAlloc(t)
+
+	cfg := defaultConfig()
+	// Simulated chains only include transactions paying a tip
+	cfg.Tx.FeeStrategy = feeStrategyPriority
+	cfg.Tx.TipWei = 1
+	cfg.Tx.Timeout = 30 * time.Second
+	cfg.Tx.ResubmitAfter = 0
+	cfg.AccessListSource = accessListSourceAdmin
+
+	admin, err := mockadmin.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start the mock admin RPC: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+	cfg.AdminRPC = admin.URL()
+
+	h := &testHarness{cfg: cfg}
+	h.origin, cfg.Origin = startSimulatedChain(t, cfg.Origin.ChainID, alloc, options...)
+	h.destination, cfg.Destination = startSimulatedChain(t, cfg.Destination.ChainID, alloc, options...)
+
+	contracts, err := json.Marshal(SupersimContracts{
+		GasTank901:       harnessGasTankAddr.Hex(),
+		GasTank902:       harnessGasTankAddr.Hex(),
+		MessageSender902: harnessMessageSenderAddr.Hex(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ContractsFile = filepath.Join(t.TempDir(), "supersim-contracts.json")
+	if err := os.WriteFile(cfg.ContractsFile, contracts, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+// harnessAlloc returns the genesis state shared by both chains: the contracts at their addresses and the gas
+// provider and relayer accounts funded
+func harnessAlloc(t *testing.T) types.GenesisAlloc {
+	t.Helper()
+	code := func(file, contract string) []byte {
+		return loadDeployedBytecode(t, file, contract)
+	}
+	funds := new(big.Int).Mul(big.NewInt(100), weiPerEther)
+	alloc := types.GenesisAlloc{
+		harnessGasTankAddr:         {Code: code("GasTank.sol", "GasTank")},
+		harnessMessageSenderAddr:   {Code: code("MessageSender.sol", "MessageSender")},
+		l2CrossDomainMessengerAddr: {Code: code("L2ToL2CrossDomainMessengerStub.sol", "L2ToL2CrossDomainMessengerStub")},
+		crossL2InboxAddr:           {Code: code("CrossL2InboxStub.sol", "CrossL2InboxStub")},
+		gasPriceOracleAddr:         {Code: code("GasPriceOracleStub.sol", "GasPriceOracleStub")},
+	}
+	cfg := defaultConfig()
+	for _, hexKey := range []string{cfg.GasProviderKey, cfg.RelayerKey} {
+		key, err := parsePrivateKey(hexKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		alloc[crypto.PubkeyToAddress(*key.Public().(*ecdsa.PublicKey))] = types.Account{Balance: funds}
+	}
+	return alloc
+}
+
+// loadDeployedBytecode reads the runtime bytecode of a contract from the forge artifacts in out/
+func loadDeployedBytecode(t *testing.T, file, contract string) []byte {
+	t.Helper()
+	// Get the path of the currently running file
+	_, b, _, _ := runtime.Caller(0)
+	path := filepath.Join(filepath.Dir(b), "..", "..", "out", file, contract+".json")
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		if os.Getenv(harnessRequiredEnv) == "1" {
+			t.Fatalf("%s not found with %s=1, run `forge build` at the repository root first", path, harnessRequiredEnv)
+		}
+		t.Skipf("%s not found, run `forge build` at the repository root first", path)
+	}
+	if err != nil {
+		t.Fatalf("failed to read artifact: %v", err)
+	}
+	var artifact struct {
+		DeployedBytecode struct {
+			Object hexutil.Bytes `json:"object"`
+		} `json:"deployedBytecode"`
+	}
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		t.Fatalf("failed to decode artifact %s: %v", path, err)
+	}
+	if len(artifact.DeployedBytecode.Object) == 0 {
+		t.Fatalf("artifact %s has no deployed bytecode", path)
+	}
+	return artifact.DeployedBytecode.Object
+}
+
+// startSimulatedChain starts a chain served over HTTP that seals a block every harnessCommitInterval. The options
+// are applied after the defaults, e.g. simulated.WithMinerMinTip to leave low-tip transactions pending.
+func startSimulatedChain(t *testing.T, chainID uint64, alloc types.GenesisAlloc, options ...func(*node.Config, *ethconfig.Config)) (*simulated.Backend, ChainConfig) {
+	t.Helper()
+	port := freePort(t)
+	defaults := []func(*node.Config, *ethconfig.Config){simulated.WithMinerMinTip(big.NewInt(1)), func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		chainConfig := *params.AllDevChainProtocolChanges
+		chainConfig.ChainID = new(big.Int).SetUint64(chainID)
+		ethConf.Genesis.Config = &chainConfig
+		ethConf.NetworkId = chainID
+
+		nodeConf.HTTPHost = "127.0.0.1"
+		nodeConf.HTTPPort = port
+		nodeConf.HTTPModules = []string{"eth", "net", "web3"}
+		nodeConf.HTTPVirtualHosts = []string{"*"}
+	}}
+	backend := simulated.NewBackend(alloc, append(defaults, options...)...)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(harnessCommitInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				backend.Commit()
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		close(done)
+		wg.Wait()
+		backend.Close()
+	})
+	return backend, ChainConfig{RPC: fmt.Sprintf("http://127.0.0.1:%d", port), ChainID: chainID}
+}
+
+// freePort returns a TCP port nothing listens on
+func freePort(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
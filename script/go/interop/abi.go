@@ -0,0 +1,23 @@
+package interop
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+var (
+	bytes32Type, _      = abi.NewType("bytes32", "", nil)
+	bytes32ArrayType, _ = abi.NewType("bytes32[]", "", nil)
+	uint256Type, _      = abi.NewType("uint256", "", nil)
+	addressType, _      = abi.NewType("address", "", nil)
+	bytesType, _        = abi.NewType("bytes", "", nil)
+
+	// sentMessageTopics are the indexed fields of SentMessage: destination, target and messageNonce
+	sentMessageTopics = abi.Arguments{{Type: uint256Type}, {Type: addressType}, {Type: uint256Type}}
+	// sentMessageData are the non-indexed fields of SentMessage: sender and message
+	sentMessageData = abi.Arguments{{Type: addressType}, {Type: bytesType}}
+
+	// gasReceiptTopics are the indexed fields of RelayedMessageGasReceipt: messageHash and relayer
+	gasReceiptTopics = abi.Arguments{{Type: bytes32Type}, {Type: addressType}}
+	// gasReceiptData are the non-indexed fields of RelayedMessageGasReceipt: relayCost and nestedMessageHashes
+	gasReceiptData = abi.Arguments{{Type: uint256Type}, {Type: bytes32ArrayType}}
+)
@@ -0,0 +1,114 @@
+package interop
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CrossL2InboxAddress is the CrossL2Inbox predeploy whose storage slots the access list entries warm
+var CrossL2InboxAddress = common.HexToAddress("0x4200000000000000000000000000000000000022")
+
+// Prefixes of the CrossL2Inbox access list entries
+const (
+	// AccessListLookupPrefix marks the entry packing the chain ID, block number, timestamp and log index
+	AccessListLookupPrefix = 0x01
+	// AccessListChainIDExtensionPrefix marks the entry carrying the upper bytes of a chain ID wider than 64 bits
+	AccessListChainIDExtensionPrefix = 0x02
+	// AccessListChecksumPrefix replaces the first byte of the checksum entry
+	AccessListChecksumPrefix = 0x03
+)
+
+// identifierFields are the Identifier fields narrowed to the widths the access list entries pack them in
+type identifierFields struct {
+	blockNumber uint64
+	timestamp   uint64
+	logIndex    uint32
+	chainID     [32]byte
+}
+
+func (id Identifier) fields() (identifierFields, error) {
+	if id.BlockNumber == nil || id.Timestamp == nil || id.LogIndex == nil || id.ChainID == nil {
+		return identifierFields{}, fmt.Errorf("identifier has unset fields")
+	}
+	if !id.BlockNumber.IsUint64() || !id.Timestamp.IsUint64() {
+		return identifierFields{}, fmt.Errorf("identifier block number %s or timestamp %s exceeds 64 bits", id.BlockNumber, id.Timestamp)
+	}
+	if !id.LogIndex.IsUint64() || id.LogIndex.Uint64() > math.MaxUint32 {
+		return identifierFields{}, fmt.Errorf("identifier log index %s exceeds 32 bits", id.LogIndex)
+	}
+	if id.ChainID.Sign() < 0 || id.ChainID.BitLen() > 256 {
+		return identifierFields{}, fmt.Errorf("identifier chain ID %s is not a uint256", id.ChainID)
+	}
+	fields := identifierFields{
+		blockNumber: id.BlockNumber.Uint64(),
+		timestamp:   id.Timestamp.Uint64(),
+		logIndex:    uint32(id.LogIndex.Uint64()),
+	}
+	id.ChainID.FillBytes(fields.chainID[:])
+	return fields, nil
+}
+
+// Checksum mirrors CrossL2Inbox.calculateChecksum: the storage key validateMessage checks is warm for the message
+// with the given payload hash, keccak256 of the log's topics followed by its data.
+func Checksum(id Identifier, payloadHash common.Hash) (common.Hash, error) {
+	fields, err := id.fields()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	logHash := crypto.Keccak256Hash(id.Origin.Bytes(), payloadHash.Bytes())
+
+	// 12 bytes of padding, then the block number, timestamp and log index packed into the remaining 20 bytes
+	idPacked := make([]byte, 12, 32)
+	idPacked = binary.BigEndian.AppendUint64(idPacked, fields.blockNumber)
+	idPacked = binary.BigEndian.AppendUint64(idPacked, fields.timestamp)
+	idPacked = binary.BigEndian.AppendUint32(idPacked, fields.logIndex)
+	idLogHash := crypto.Keccak256Hash(logHash.Bytes(), idPacked)
+
+	checksum := crypto.Keccak256Hash(idLogHash.Bytes(), fields.chainID[:])
+	checksum[0] = AccessListChecksumPrefix
+	return checksum, nil
+}
+
+// AccessListStorageKeys returns the CrossL2Inbox storage keys a transaction executing the message must access:
+// the lookup entry, a chain ID extension entry when the chain ID exceeds 64 bits, and the checksum.
+func AccessListStorageKeys(id Identifier, payloadHash common.Hash) ([]common.Hash, error) {
+	fields, err := id.fields()
+	if err != nil {
+		return nil, err
+	}
+	checksum, err := Checksum(id, payloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var lookup common.Hash
+	lookup[0] = AccessListLookupPrefix
+	copy(lookup[4:12], fields.chainID[24:32])
+	binary.BigEndian.PutUint64(lookup[12:20], fields.blockNumber)
+	binary.BigEndian.PutUint64(lookup[20:28], fields.timestamp)
+	binary.BigEndian.PutUint32(lookup[28:32], fields.logIndex)
+	keys := []common.Hash{lookup}
+
+	if !id.ChainID.IsUint64() {
+		var extension common.Hash
+		extension[0] = AccessListChainIDExtensionPrefix
+		copy(extension[8:32], fields.chainID[0:24])
+		keys = append(keys, extension)
+	}
+	return append(keys, checksum), nil
+}
+
+// NewAccessList returns the access list of a transaction executing the message with the given payload, the
+// one supersim's admin_getAccessListForIdentifier returns.
+func NewAccessList(id Identifier, payload []byte) (types.AccessList, error) {
+	keys, err := AccessListStorageKeys(id, crypto.Keccak256Hash(payload))
+	if err != nil {
+		return nil, err
+	}
+	return types.AccessList{{Address: CrossL2InboxAddress, StorageKeys: keys}}, nil
+}
@@ -0,0 +1,100 @@
+package interop
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The expected storage keys were computed independently of this package from the CrossL2Inbox encoding:
+// checksum = keccak256(keccak256(keccak256(origin ++ payloadHash) ++ idPacked) ++ chainId) with its first byte
+// set to 0x03, preceded by the 0x01 lookup entry and, for chain IDs wider than 64 bits, the 0x02 extension entry.
+var accessListTests = []struct {
+	name    string
+	id      Identifier
+	payload []byte
+	want    []common.Hash
+}{
+	{
+		name: "relayed message payload",
+		id: Identifier{
+			Origin:      common.HexToAddress("0x4200000000000000000000000000000000000023"),
+			BlockNumber: big.NewInt(12345),
+			LogIndex:    big.NewInt(2),
+			Timestamp:   big.NewInt(1700000000),
+			ChainID:     big.NewInt(901),
+		},
+		payload: hexutil.MustDecode("0x382409ac69001e11931a28435afef442cbfd20d9891907e8fa373ba7d351f320" + "0000000000000000000000000000000000000000000000000000000000000384"),
+		want: []common.Hash{
+			common.HexToHash("0x0100000000000000000003850000000000003039000000006553f10000000002"),
+			common.HexToHash("0x0311eaaf9702acfeac799f42a5bcc10582603152b17ea49876a15ad8ec95b991"),
+		},
+	},
+	{
+		name: "chain ID wider than 64 bits",
+		id: Identifier{
+			Origin:      common.HexToAddress("0x4200000000000000000000000000000000000023"),
+			BlockNumber: big.NewInt(7),
+			LogIndex:    big.NewInt(0),
+			Timestamp:   big.NewInt(1750000000),
+			ChainID:     new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(5)),
+		},
+		payload: []byte{},
+		want: []common.Hash{
+			common.HexToHash("0x010000000000000000000005000000000000000700000000684ee18000000000"),
+			common.HexToHash("0x0200000000000000000000000000000000000000000000000000000000000001"),
+			common.HexToHash("0x0304b986c0242a7b0aafa94f282d6b146940a84befd2214584ef057d72a4ea60"),
+		},
+	},
+}
+
+func TestNewAccessList(t *testing.T) {
+	for _, tt := range accessListTests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessList, err := NewAccessList(tt.id, tt.payload)
+			if err != nil {
+				t.Fatalf("NewAccessList: %v", err)
+			}
+			if len(accessList) != 1 || accessList[0].Address != CrossL2InboxAddress {
+				t.Fatalf("access list = %v, want a single CrossL2Inbox entry", accessList)
+			}
+			if !slices.Equal(accessList[0].StorageKeys, tt.want) {
+				t.Errorf("storage keys = %v, want %v", accessList[0].StorageKeys, tt.want)
+			}
+
+			checksum, err := Checksum(tt.id, crypto.Keccak256Hash(tt.payload))
+			if err != nil {
+				t.Fatalf("Checksum: %v", err)
+			}
+			if want := tt.want[len(tt.want)-1]; checksum != want {
+				t.Errorf("checksum = %s, want %s", checksum.Hex(), want.Hex())
+			}
+		})
+	}
+}
+
+func TestNewAccessListInvalidIdentifier(t *testing.T) {
+	valid := accessListTests[0].id
+	tests := []struct {
+		name   string
+		modify func(id *Identifier)
+	}{
+		{"unset block number", func(id *Identifier) { id.BlockNumber = nil }},
+		{"block number over 64 bits", func(id *Identifier) { id.BlockNumber = new(big.Int).Lsh(big.NewInt(1), 64) }},
+		{"log index over 32 bits", func(id *Identifier) { id.LogIndex = big.NewInt(1 << 32) }},
+		{"negative chain ID", func(id *Identifier) { id.ChainID = big.NewInt(-1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := valid
+			tt.modify(&id)
+			if _, err := NewAccessList(id, nil); err == nil {
+				t.Error("NewAccessList succeeded, want an error")
+			}
+		})
+	}
+}
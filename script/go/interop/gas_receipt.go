@@ -0,0 +1,106 @@
+package interop
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// RelayedMessageGasReceiptTopic is the event signature of GasTank.RelayedMessageGasReceipt
+var RelayedMessageGasReceiptTopic = crypto.Keccak256Hash([]byte("RelayedMessageGasReceipt(bytes32,address,uint256,bytes32[])"))
+
+// GasReceipt mirrors the fields of the GasTank.RelayedMessageGasReceipt event
+type GasReceipt struct {
+	MessageHash         common.Hash
+	Relayer             common.Address
+	RelayCost           *big.Int
+	NestedMessageHashes []common.Hash
+}
+
+// GasReceiptFromLog decodes a RelayedMessageGasReceipt log
+func GasReceiptFromLog(log *types.Log) (*GasReceipt, error) {
+	if len(log.Topics) != 3 || log.Topics[0] != RelayedMessageGasReceiptTopic {
+		return nil, fmt.Errorf("%w: log is not a RelayedMessageGasReceipt event", ErrInvalidPayload)
+	}
+
+	// Non-indexed fields are in Data
+	unpackedData, err := gasReceiptData.Unpack(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unpack RelayedMessageGasReceipt event data: %v", ErrInvalidPayload, err)
+	}
+
+	// Indexed fields are in Topics
+	return &GasReceipt{
+		MessageHash:         log.Topics[1],
+		Relayer:             common.BytesToAddress(log.Topics[2].Bytes()),
+		RelayCost:           unpackedData[0].(*big.Int),
+		NestedMessageHashes: toHashes(unpackedData[1].([][32]byte)),
+	}, nil
+}
+
+// Encode builds the payload expected by GasTank.claim, laid out as decodeGasReceiptPayload reads it:
+// the event selector, the ABI encoded topics at [32:96] and the ABI encoded data at [96:].
+func (r *GasReceipt) Encode() ([]byte, error) {
+	encodedTopics, err := gasReceiptTopics.Pack(r.MessageHash, r.Relayer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack topics for claim payload: %w", err)
+	}
+	encodedData, err := gasReceiptData.Pack(r.relayCost(), fromHashes(r.NestedMessageHashes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data for claim payload: %w", err)
+	}
+
+	payload := append(RelayedMessageGasReceiptTopic.Bytes(), encodedTopics...)
+	return append(payload, encodedData...), nil
+}
+
+// DecodeGasReceipt decodes a claim payload the same way GasTank.decodeGasReceiptPayload does
+func DecodeGasReceipt(payload []byte) (*GasReceipt, error) {
+	if len(payload) < 96 || common.BytesToHash(payload[:32]) != RelayedMessageGasReceiptTopic {
+		return nil, fmt.Errorf("%w: payload is not a RelayedMessageGasReceipt event", ErrInvalidPayload)
+	}
+
+	// Decode Topics
+	unpackedTopics, err := gasReceiptTopics.Unpack(payload[32:96])
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unpack RelayedMessageGasReceipt topics: %v", ErrInvalidPayload, err)
+	}
+	// Decode Data
+	unpackedData, err := gasReceiptData.Unpack(payload[96:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unpack RelayedMessageGasReceipt data: %v", ErrInvalidPayload, err)
+	}
+
+	return &GasReceipt{
+		MessageHash:         common.Hash(unpackedTopics[0].([32]byte)),
+		Relayer:             unpackedTopics[1].(common.Address),
+		RelayCost:           unpackedData[0].(*big.Int),
+		NestedMessageHashes: toHashes(unpackedData[1].([][32]byte)),
+	}, nil
+}
+
+func (r *GasReceipt) relayCost() *big.Int {
+	if r.RelayCost == nil {
+		return new(big.Int)
+	}
+	return r.RelayCost
+}
+
+func toHashes(raw [][32]byte) []common.Hash {
+	hashes := make([]common.Hash, len(raw))
+	for i, h := range raw {
+		hashes[i] = h
+	}
+	return hashes
+}
+
+func fromHashes(hashes []common.Hash) [][32]byte {
+	raw := make([][32]byte, len(hashes))
+	for i, h := range hashes {
+		raw[i] = h
+	}
+	return raw
+}
@@ -0,0 +1,29 @@
+package interop
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// messageHashArgs is the abi.encode layout hashed by Hashing.hashL2toL2CrossDomainMessage
+var messageHashArgs = abi.Arguments{{Type: uint256Type}, {Type: uint256Type}, {Type: uint256Type}, {Type: addressType}, {Type: addressType}, {Type: bytesType}}
+
+// HashL2toL2CrossDomainMessage mirrors Hashing.hashL2toL2CrossDomainMessage:
+// keccak256(abi.encode(_destination, _source, _nonce, _sender, _target, _message))
+func HashL2toL2CrossDomainMessage(destination, source, nonce *big.Int, sender, target common.Address, message []byte) (common.Hash, error) {
+	encoded, err := messageHashArgs.Pack(destination, source, nonce, sender, target, message)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode message for hashing: %w", err)
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// Hash returns the hash the messenger and the GasTank use to identify the message, given the chain ID of the
+// chain it was sent from. It matches the messageHash indexed in the RelayedMessageGasReceipt of its relay.
+func (m *SentMessage) Hash(source *big.Int) (common.Hash, error) {
+	return HashL2toL2CrossDomainMessage(m.Destination, source, m.Nonce, m.Sender, m.Target, m.Message)
+}
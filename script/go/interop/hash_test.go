@@ -0,0 +1,94 @@
+package interop
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// The expected hashes were computed independently of go-ethereum's ABI encoder from
+// keccak256(abi.encode(destination, source, nonce, sender, target, message)).
+var messageHashTests = []struct {
+	name        string
+	destination int64
+	source      int64
+	nonce       int64
+	sender      common.Address
+	target      common.Address
+	message     []byte
+	want        common.Hash
+}{
+	{
+		name:        "nested message sent by MessageSender",
+		destination: 901,
+		source:      902,
+		nonce:       0,
+		sender:      common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3"),
+		target:      common.HexToAddress("0x1234567890123456789012345678901234567890"),
+		message:     []byte{},
+		want:        common.HexToHash("0xaca36a22ae637e6bb8dacd97a2e94f1ab58cfba5da94590673560d9d8f7331e4"),
+	},
+	{
+		name:        "message with calldata",
+		destination: 902,
+		source:      901,
+		nonce:       42,
+		sender:      common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+		target:      common.HexToAddress("0x4200000000000000000000000000000000000024"),
+		message:     hexutil.MustDecode("0xa9059cbb0000000000000000000000001234567890123456789012345678901234567890" + "0000000000000000000000000000000000000000000000000000000000000064"),
+		want:        common.HexToHash("0xe69beced65fdbed98811954faa87cb9df9030a51c26dae2aab724547eb3ee417"),
+	},
+}
+
+func TestHashL2toL2CrossDomainMessage(t *testing.T) {
+	for _, tt := range messageHashTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HashL2toL2CrossDomainMessage(big.NewInt(tt.destination), big.NewInt(tt.source), big.NewInt(tt.nonce), tt.sender, tt.target, tt.message)
+			if err != nil {
+				t.Fatalf("HashL2toL2CrossDomainMessage: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("hash = %s, want %s", got.Hex(), tt.want.Hex())
+			}
+		})
+	}
+}
+
+// sentMessageLog builds the log the messenger emits for m
+func sentMessageLog(t *testing.T, m *SentMessage) *types.Log {
+	t.Helper()
+	data, err := sentMessageData.Pack(m.Sender, m.Message)
+	if err != nil {
+		t.Fatalf("failed to pack SentMessage data: %v", err)
+	}
+	return &types.Log{
+		Address: common.HexToAddress("0x4200000000000000000000000000000000000023"),
+		Topics: []common.Hash{
+			SentMessageTopic,
+			common.BigToHash(m.Destination),
+			common.BytesToHash(m.Target.Bytes()),
+			common.BigToHash(m.Nonce),
+		},
+		Data: data,
+	}
+}
+
+// gasReceiptLog builds the log the GasTank emits for r
+func gasReceiptLog(t *testing.T, r *GasReceipt) *types.Log {
+	t.Helper()
+	data, err := gasReceiptData.Pack(r.RelayCost, fromHashes(r.NestedMessageHashes))
+	if err != nil {
+		t.Fatalf("failed to pack RelayedMessageGasReceipt data: %v", err)
+	}
+	return &types.Log{
+		Topics: []common.Hash{
+			RelayedMessageGasReceiptTopic,
+			r.MessageHash,
+			common.BytesToHash(r.Relayer.Bytes()),
+		},
+		Data: data,
+	}
+}
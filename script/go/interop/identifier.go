@@ -0,0 +1,31 @@
+// Package interop encodes and decodes the payloads exchanged between the L2ToL2CrossDomainMessenger,
+// the GasTank and the CrossL2Inbox, so they can be rebuilt from the logs they were emitted as.
+package interop
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Identifier matches the ICrossL2Inbox.Identifier struct
+type Identifier struct {
+	Origin      common.Address `json:"origin" abi:"origin"`
+	BlockNumber *big.Int       `json:"blockNumber" abi:"blockNumber"`
+	LogIndex    *big.Int       `json:"logIndex" abi:"logIndex"`
+	Timestamp   *big.Int       `json:"timestamp" abi:"timestamp"`
+	ChainID     *big.Int       `json:"chainId" abi:"chainId"`
+}
+
+// NewIdentifier builds the Identifier of a log emitted on the chain with the given chain ID.
+// The header must be the one of the block that contains the log.
+func NewIdentifier(log *types.Log, header *types.Header, chainID *big.Int) Identifier {
+	return Identifier{
+		Origin:      log.Address,
+		BlockNumber: new(big.Int).SetUint64(log.BlockNumber),
+		LogIndex:    new(big.Int).SetUint64(uint64(log.Index)),
+		Timestamp:   new(big.Int).SetUint64(header.Time),
+		ChainID:     new(big.Int).Set(chainID),
+	}
+}
@@ -0,0 +1,459 @@
+package interop
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// The expected payloads are assembled word by word from the event layouts rather than with the ABI encoder:
+// the selector, one word per topic, then the head and tail of the data.
+var sentMessagePayloadTests = []struct {
+	name    string
+	message *SentMessage
+	want    []byte
+}{
+	{
+		name: "empty message",
+		message: &SentMessage{
+			Destination: big.NewInt(902),
+			Target:      common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512"),
+			Nonce:       big.NewInt(0),
+			Sender:      common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3"),
+			Message:     []byte{},
+		},
+		want: words(
+			SentMessageTopic.Bytes(),
+			word(902),
+			common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512").Bytes(),
+			word(0),
+			common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3").Bytes(),
+			word(0x40),
+			word(0),
+		),
+	},
+	{
+		name: "message shorter than a word",
+		message: &SentMessage{
+			Destination: big.NewInt(901),
+			Target:      common.HexToAddress("0x4200000000000000000000000000000000000024"),
+			Nonce:       big.NewInt(42),
+			Sender:      common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+			Message:     hexutil.MustDecode("0xa9059cbb01"),
+		},
+		want: words(
+			SentMessageTopic.Bytes(),
+			word(901),
+			common.HexToAddress("0x4200000000000000000000000000000000000024").Bytes(),
+			word(42),
+			common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266").Bytes(),
+			word(0x40),
+			word(5),
+			common.RightPadBytes(hexutil.MustDecode("0xa9059cbb01"), 32),
+		),
+	},
+	{
+		name: "maximum destination and nonce",
+		message: &SentMessage{
+			Destination: math.MaxBig256,
+			Target:      common.Address{},
+			Nonce:       math.MaxBig256,
+			Sender:      common.Address{},
+			Message:     bytes.Repeat([]byte{0xab}, 64),
+		},
+		want: words(
+			SentMessageTopic.Bytes(),
+			math.MaxBig256.Bytes(),
+			word(0),
+			math.MaxBig256.Bytes(),
+			word(0),
+			word(0x40),
+			word(64),
+			bytes.Repeat([]byte{0xab}, 32),
+			bytes.Repeat([]byte{0xab}, 32),
+		),
+	},
+}
+
+var gasReceiptPayloadTests = []struct {
+	name    string
+	receipt *GasReceipt
+	want    []byte
+}{
+	{
+		name: "no nested messages",
+		receipt: &GasReceipt{
+			MessageHash: common.HexToHash("0xaca36a22ae637e6bb8dacd97a2e94f1ab58cfba5da94590673560d9d8f7331e4"),
+			Relayer:     common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+			RelayCost:   big.NewInt(123456789),
+		},
+		want: words(
+			RelayedMessageGasReceiptTopic.Bytes(),
+			common.HexToHash("0xaca36a22ae637e6bb8dacd97a2e94f1ab58cfba5da94590673560d9d8f7331e4").Bytes(),
+			common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8").Bytes(),
+			word(123456789),
+			word(0x40),
+			word(0),
+		),
+	},
+	{
+		name: "nested messages",
+		receipt: &GasReceipt{
+			MessageHash:         common.HexToHash("0xe69beced65fdbed98811954faa87cb9df9030a51c26dae2aab724547eb3ee417"),
+			Relayer:             common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+			RelayCost:           math.MaxBig256,
+			NestedMessageHashes: []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")},
+		},
+		want: words(
+			RelayedMessageGasReceiptTopic.Bytes(),
+			common.HexToHash("0xe69beced65fdbed98811954faa87cb9df9030a51c26dae2aab724547eb3ee417").Bytes(),
+			common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8").Bytes(),
+			math.MaxBig256.Bytes(),
+			word(0x40),
+			word(2),
+			word(1),
+			word(2),
+		),
+	},
+}
+
+func TestSentMessagePayload(t *testing.T) {
+	for _, tt := range sentMessagePayloadTests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkSentMessagePayload(t, tt.message, tt.want)
+		})
+	}
+}
+
+func TestGasReceiptPayload(t *testing.T) {
+	for _, tt := range gasReceiptPayloadTests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkGasReceiptPayload(t, tt.receipt, tt.want)
+		})
+	}
+}
+
+// TestGasReceiptPayloadManyNestedMessages round-trips a receipt with more nested messages than any run relays
+func TestGasReceiptPayloadManyNestedMessages(t *testing.T) {
+	const nested = 1000
+	receipt := &GasReceipt{
+		MessageHash: common.HexToHash("0xaca36a22ae637e6bb8dacd97a2e94f1ab58cfba5da94590673560d9d8f7331e4"),
+		Relayer:     common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		RelayCost:   big.NewInt(1),
+	}
+	want := words(
+		RelayedMessageGasReceiptTopic.Bytes(),
+		receipt.MessageHash.Bytes(),
+		receipt.Relayer.Bytes(),
+		word(1),
+		word(0x40),
+		word(nested),
+	)
+	for i := range nested {
+		hash := common.BigToHash(big.NewInt(int64(i) + 1))
+		receipt.NestedMessageHashes = append(receipt.NestedMessageHashes, hash)
+		want = append(want, hash.Bytes()...)
+	}
+	checkGasReceiptPayload(t, receipt, want)
+}
+
+func TestDecodeSentMessageMalformed(t *testing.T) {
+	valid := sentMessagePayloadTests[1].want
+	tests := []struct {
+		name    string
+		payload []byte
+	}{
+		{"empty", nil},
+		{"selector only", valid[:32]},
+		{"truncated topics", valid[:127]},
+		{"topics only", valid[:128]},
+		{"wrong selector", append(RelayedMessageGasReceiptTopic.Bytes(), valid[32:]...)},
+		// Like abi.decode, the decoder accepts a message missing its zero padding but not one missing its bytes
+		{"truncated message", valid[:len(valid)-28]},
+		{"message offset out of bounds", replaceWord(valid, 5, word(0x1000))},
+		{"message length out of bounds", replaceWord(valid, 6, word(33))},
+		{"message length overflow", replaceWord(valid, 6, math.MaxBig256.Bytes())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if m, err := DecodeSentMessage(tt.payload); !errors.Is(err, ErrInvalidPayload) {
+				t.Errorf("DecodeSentMessage = %+v, %v, want %v", m, err, ErrInvalidPayload)
+			}
+		})
+	}
+}
+
+func TestDecodeGasReceiptMalformed(t *testing.T) {
+	valid := gasReceiptPayloadTests[1].want
+	tests := []struct {
+		name    string
+		payload []byte
+	}{
+		{"empty", nil},
+		{"selector only", valid[:32]},
+		{"truncated topics", valid[:95]},
+		{"topics only", valid[:96]},
+		{"wrong selector", append(SentMessageTopic.Bytes(), valid[32:]...)},
+		{"sent message payload", sentMessagePayloadTests[0].want},
+		{"missing nested hash", valid[:len(valid)-32]},
+		{"truncated nested hash", valid[:len(valid)-1]},
+		{"array offset out of bounds", replaceWord(valid, 4, word(0x1000))},
+		{"array length out of bounds", replaceWord(valid, 5, word(3))},
+		{"array length overflow", replaceWord(valid, 5, math.MaxBig256.Bytes())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if r, err := DecodeGasReceipt(tt.payload); !errors.Is(err, ErrInvalidPayload) {
+				t.Errorf("DecodeGasReceipt = %+v, %v, want %v", r, err, ErrInvalidPayload)
+			}
+		})
+	}
+}
+
+func TestFromLogMalformed(t *testing.T) {
+	sentLog := sentMessageLog(t, sentMessagePayloadTests[1].message)
+	receiptLog := gasReceiptLog(t, gasReceiptPayloadTests[1].receipt)
+	tests := []struct {
+		name   string
+		decode func(*types.Log) error
+		log    *types.Log
+	}{
+		{"SentMessage without topics", sentMessageFromLog, &types.Log{Data: sentLog.Data}},
+		{"SentMessage missing a topic", sentMessageFromLog, &types.Log{Topics: sentLog.Topics[:3], Data: sentLog.Data}},
+		{"SentMessage of another event", sentMessageFromLog, &types.Log{Topics: slices.Concat([]common.Hash{RelayedMessageGasReceiptTopic}, sentLog.Topics[1:]), Data: sentLog.Data}},
+		{"SentMessage with truncated data", sentMessageFromLog, &types.Log{Topics: sentLog.Topics, Data: sentLog.Data[:len(sentLog.Data)-28]}},
+		{"RelayedMessageGasReceipt without topics", gasReceiptFromLog, &types.Log{Data: receiptLog.Data}},
+		{"RelayedMessageGasReceipt with an extra topic", gasReceiptFromLog, &types.Log{Topics: append(slices.Clone(receiptLog.Topics), common.Hash{}), Data: receiptLog.Data}},
+		{"RelayedMessageGasReceipt of another event", gasReceiptFromLog, &types.Log{Topics: slices.Concat([]common.Hash{SentMessageTopic}, receiptLog.Topics[1:]), Data: receiptLog.Data}},
+		{"RelayedMessageGasReceipt with truncated data", gasReceiptFromLog, &types.Log{Topics: receiptLog.Topics, Data: receiptLog.Data[:len(receiptLog.Data)-32]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.decode(tt.log); !errors.Is(err, ErrInvalidPayload) {
+				t.Errorf("error = %v, want %v", err, ErrInvalidPayload)
+			}
+		})
+	}
+}
+
+// FuzzSentMessagePayload checks that the payload rebuilt from any SentMessage log is the log's own payload and
+// decodes back to the event fields
+func FuzzSentMessagePayload(f *testing.F) {
+	for _, tt := range sentMessagePayloadTests {
+		m := tt.message
+		f.Add(m.Destination.Bytes(), m.Target.Bytes(), m.Nonce.Bytes(), m.Sender.Bytes(), m.Message)
+	}
+	f.Fuzz(func(t *testing.T, destination, target, nonce, sender, message []byte) {
+		checkSentMessagePayload(t, &SentMessage{
+			Destination: new(big.Int).SetBytes(truncate(destination, 32)),
+			Target:      common.BytesToAddress(target),
+			Nonce:       new(big.Int).SetBytes(truncate(nonce, 32)),
+			Sender:      common.BytesToAddress(sender),
+			Message:     message,
+		}, nil)
+	})
+}
+
+// FuzzGasReceiptPayload checks that the claim payload rebuilt from any RelayedMessageGasReceipt log is the log's
+// own payload and decodes back to the event fields. nested is cut into 32 byte nested message hashes.
+func FuzzGasReceiptPayload(f *testing.F) {
+	for _, tt := range gasReceiptPayloadTests {
+		r := tt.receipt
+		f.Add(r.MessageHash.Bytes(), r.Relayer.Bytes(), r.RelayCost.Bytes(), slices.Concat(hashBytes(r.NestedMessageHashes)...))
+	}
+	f.Fuzz(func(t *testing.T, messageHash, relayer, relayCost, nested []byte) {
+		receipt := &GasReceipt{
+			MessageHash: common.BytesToHash(messageHash),
+			Relayer:     common.BytesToAddress(relayer),
+			RelayCost:   new(big.Int).SetBytes(truncate(relayCost, 32)),
+		}
+		for chunk := range slices.Chunk(nested, 32) {
+			receipt.NestedMessageHashes = append(receipt.NestedMessageHashes, common.BytesToHash(chunk))
+		}
+		checkGasReceiptPayload(t, receipt, nil)
+	})
+}
+
+// FuzzDecodeSentMessage feeds arbitrary payloads to DecodeSentMessage, which must reject them with
+// ErrInvalidPayload or return a message that encodes to a payload decoding to the same message
+func FuzzDecodeSentMessage(f *testing.F) {
+	for _, tt := range sentMessagePayloadTests {
+		f.Add(tt.want)
+	}
+	f.Add(replaceWord(sentMessagePayloadTests[1].want, 6, math.MaxBig256.Bytes()))
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		m, err := DecodeSentMessage(payload)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidPayload) {
+				t.Fatalf("error = %v, want %v", err, ErrInvalidPayload)
+			}
+			return
+		}
+		encoded, err := m.Encode()
+		if err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+		got, err := DecodeSentMessage(encoded)
+		if err != nil {
+			t.Fatalf("DecodeSentMessage of the re-encoded payload: %v", err)
+		}
+		if !equalSentMessages(got, m) {
+			t.Errorf("re-encoded message = %+v, want %+v", got, m)
+		}
+	})
+}
+
+// FuzzDecodeGasReceipt feeds arbitrary payloads to DecodeGasReceipt, which must reject them with
+// ErrInvalidPayload or return a receipt that encodes to a payload decoding to the same receipt
+func FuzzDecodeGasReceipt(f *testing.F) {
+	for _, tt := range gasReceiptPayloadTests {
+		f.Add(tt.want)
+	}
+	f.Add(replaceWord(gasReceiptPayloadTests[1].want, 5, math.MaxBig256.Bytes()))
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		r, err := DecodeGasReceipt(payload)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidPayload) {
+				t.Fatalf("error = %v, want %v", err, ErrInvalidPayload)
+			}
+			return
+		}
+		encoded, err := r.Encode()
+		if err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+		got, err := DecodeGasReceipt(encoded)
+		if err != nil {
+			t.Fatalf("DecodeGasReceipt of the re-encoded payload: %v", err)
+		}
+		if !equalGasReceipts(got, r) {
+			t.Errorf("re-encoded receipt = %+v, want %+v", got, r)
+		}
+	})
+}
+
+// checkSentMessagePayload goes from the log of m to the relay payload and back. The payload must equal want
+// when it is set.
+func checkSentMessagePayload(t *testing.T, m *SentMessage, want []byte) {
+	t.Helper()
+	log := sentMessageLog(t, m)
+	fromLog, err := SentMessageFromLog(log)
+	if err != nil {
+		t.Fatalf("SentMessageFromLog: %v", err)
+	}
+	if !equalSentMessages(fromLog, m) {
+		t.Fatalf("SentMessageFromLog = %+v, want %+v", fromLog, m)
+	}
+
+	payload, err := fromLog.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if want != nil && !bytes.Equal(payload, want) {
+		t.Fatalf("payload = %x, want %x", payload, want)
+	}
+	if logPayload := LogPayload(log); !bytes.Equal(payload, logPayload) {
+		t.Fatalf("payload = %x, LogPayload = %x", payload, logPayload)
+	}
+
+	decoded, err := DecodeSentMessage(payload)
+	if err != nil {
+		t.Fatalf("DecodeSentMessage: %v", err)
+	}
+	if !equalSentMessages(decoded, m) {
+		t.Fatalf("DecodeSentMessage = %+v, want %+v", decoded, m)
+	}
+}
+
+// checkGasReceiptPayload goes from the log of r to the claim payload and back. The payload must equal want when
+// it is set.
+func checkGasReceiptPayload(t *testing.T, r *GasReceipt, want []byte) {
+	t.Helper()
+	log := gasReceiptLog(t, r)
+	fromLog, err := GasReceiptFromLog(log)
+	if err != nil {
+		t.Fatalf("GasReceiptFromLog: %v", err)
+	}
+	if !equalGasReceipts(fromLog, r) {
+		t.Fatalf("GasReceiptFromLog = %+v, want %+v", fromLog, r)
+	}
+
+	payload, err := fromLog.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if want != nil && !bytes.Equal(payload, want) {
+		t.Fatalf("payload = %x, want %x", payload, want)
+	}
+	if logPayload := LogPayload(log); !bytes.Equal(payload, logPayload) {
+		t.Fatalf("payload = %x, LogPayload = %x", payload, logPayload)
+	}
+
+	decoded, err := DecodeGasReceipt(payload)
+	if err != nil {
+		t.Fatalf("DecodeGasReceipt: %v", err)
+	}
+	if !equalGasReceipts(decoded, r) {
+		t.Fatalf("DecodeGasReceipt = %+v, want %+v", decoded, r)
+	}
+}
+
+func sentMessageFromLog(log *types.Log) error {
+	_, err := SentMessageFromLog(log)
+	return err
+}
+
+func gasReceiptFromLog(log *types.Log) error {
+	_, err := GasReceiptFromLog(log)
+	return err
+}
+
+func equalSentMessages(a, b *SentMessage) bool {
+	return a.Destination.Cmp(b.Destination) == 0 && a.Target == b.Target && a.Nonce.Cmp(b.Nonce) == 0 &&
+		a.Sender == b.Sender && bytes.Equal(a.Message, b.Message)
+}
+
+func equalGasReceipts(a, b *GasReceipt) bool {
+	return a.MessageHash == b.MessageHash && a.Relayer == b.Relayer && a.relayCost().Cmp(b.relayCost()) == 0 &&
+		slices.Equal(a.NestedMessageHashes, b.NestedMessageHashes)
+}
+
+// words left pads every value to 32 bytes and concatenates them
+func words(values ...[]byte) []byte {
+	var out []byte
+	for _, v := range values {
+		out = append(out, common.LeftPadBytes(v, 32)...)
+	}
+	return out
+}
+
+// word encodes n as a 32 byte big-endian word
+func word(n uint64) []byte {
+	return new(big.Int).SetUint64(n).FillBytes(make([]byte, 32))
+}
+
+// replaceWord returns a copy of payload with its i-th 32 byte word set to w
+func replaceWord(payload []byte, i int, w []byte) []byte {
+	out := slices.Clone(payload)
+	copy(out[i*32:(i+1)*32], common.LeftPadBytes(w, 32))
+	return out
+}
+
+func truncate(b []byte, n int) []byte {
+	return b[:min(len(b), n)]
+}
+
+func hashBytes(hashes []common.Hash) [][]byte {
+	out := make([][]byte, len(hashes))
+	for i, h := range hashes {
+		out[i] = h.Bytes()
+	}
+	return out
+}
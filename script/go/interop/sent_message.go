@@ -0,0 +1,99 @@
+package interop
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SentMessageTopic is the event signature of L2ToL2CrossDomainMessenger.SentMessage
+var SentMessageTopic = crypto.Keccak256Hash([]byte("SentMessage(uint256,address,uint256,address,bytes)"))
+
+// ErrInvalidPayload is returned when a payload or log does not match the expected event layout
+var ErrInvalidPayload = errors.New("invalid payload")
+
+// SentMessage mirrors the fields of the L2ToL2CrossDomainMessenger.SentMessage event
+type SentMessage struct {
+	Destination *big.Int
+	Target      common.Address
+	Nonce       *big.Int
+	Sender      common.Address
+	Message     []byte
+}
+
+// SentMessageFromLog decodes a SentMessage log
+func SentMessageFromLog(log *types.Log) (*SentMessage, error) {
+	if len(log.Topics) != 4 || log.Topics[0] != SentMessageTopic {
+		return nil, fmt.Errorf("%w: log is not a SentMessage event", ErrInvalidPayload)
+	}
+
+	// Non-indexed fields are in Data
+	unpackedData, err := sentMessageData.Unpack(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unpack SentMessage event data: %v", ErrInvalidPayload, err)
+	}
+
+	// Indexed fields are in Topics
+	return &SentMessage{
+		Destination: new(big.Int).SetBytes(log.Topics[1].Bytes()),
+		Target:      common.BytesToAddress(log.Topics[2].Bytes()),
+		Nonce:       new(big.Int).SetBytes(log.Topics[3].Bytes()),
+		Sender:      unpackedData[0].(common.Address),
+		Message:     unpackedData[1].([]byte),
+	}, nil
+}
+
+// Encode builds the _sentMessage payload expected by relayMessage: the event selector, the ABI encoded
+// topics and the ABI encoded data. It is byte for byte equal to LogPayload of the originating log.
+func (m *SentMessage) Encode() ([]byte, error) {
+	encodedTopics, err := sentMessageTopics.Pack(m.Destination, m.Target, m.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack topics for payload: %w", err)
+	}
+	encodedData, err := sentMessageData.Pack(m.Sender, m.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack data for payload: %w", err)
+	}
+
+	payload := append(SentMessageTopic.Bytes(), encodedTopics...)
+	return append(payload, encodedData...), nil
+}
+
+// DecodeSentMessage decodes a _sentMessage payload, the inverse of SentMessage.Encode
+func DecodeSentMessage(payload []byte) (*SentMessage, error) {
+	if len(payload) < 128 || common.BytesToHash(payload[:32]) != SentMessageTopic {
+		return nil, fmt.Errorf("%w: payload is not a SentMessage event", ErrInvalidPayload)
+	}
+
+	// Decode Topics
+	unpackedTopics, err := sentMessageTopics.Unpack(payload[32:128])
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unpack SentMessage topics: %v", ErrInvalidPayload, err)
+	}
+	// Decode Data
+	unpackedData, err := sentMessageData.Unpack(payload[128:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to unpack SentMessage data: %v", ErrInvalidPayload, err)
+	}
+
+	return &SentMessage{
+		Destination: unpackedTopics[0].(*big.Int),
+		Target:      unpackedTopics[1].(common.Address),
+		Nonce:       unpackedTopics[2].(*big.Int),
+		Sender:      unpackedData[0].(common.Address),
+		Message:     unpackedData[1].([]byte),
+	}, nil
+}
+
+// LogPayload concatenates the topics and data of any log, which is the payload the CrossL2Inbox validates
+func LogPayload(log *types.Log) []byte {
+	var payload []byte
+	for _, topic := range log.Topics {
+		payload = append(payload, topic.Bytes()...)
+	}
+	return append(payload, log.Data...)
+}
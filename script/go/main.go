@@ -1,36 +1,265 @@
 package main
 
 import (
+	"context"
+	"crypto/ecdsa"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"supersim-e2e-example/mockadmin"
 )
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run . <script_name>")
-		fmt.Println("Available scripts: relay, gastank --numNestedMessages <number>, gasanalysis")
+		fmt.Println("Usage: go run . <script_name> [flags]")
+		fmt.Println("Available scripts: relay, gastank --numNestedMessages <number>, gasanalysis, calibrate [results files], gasplot, export [results files], relayer, status <messageHash> [--json]")
+		fmt.Println("Gas provider accounts: gastank deposit|balance|withdraw init|withdraw finalize|withdrawal-status [--chain <id>] [--account <account>] [--amount <ETH>]")
+		fmt.Println("Every script accepts --config <file.toml> and the RPC, chain ID and key flags listed by <script_name> -h")
 		os.Exit(1)
 	}
 
+	relayCmd := flag.NewFlagSet("relay", flag.ExitOnError)
+	relayConfig := addConfigFlags(relayCmd)
+
 	gastankCmd := flag.NewFlagSet("gastank", flag.ExitOnError)
+	gastankConfig := addConfigFlags(gastankCmd)
 	numNestedMessages := gastankCmd.Int64("numNestedMessages", 5, "Number of nested messages to send.")
+	gastankDepositTarget := gastankCmd.String("depositTarget", "100%", "Gas provider balance to top up to before sending, a percentage of MAX_DEPOSIT (e.g. 50%) or an ETH amount.")
+	followNested := gastankCmd.Bool("followNested", true, "Relay and claim the nested messages produced by the relay.")
+
+	gastankAccountCmd := flag.NewFlagSet("gastank account", flag.ExitOnError)
+	gastankAccountConfig := addConfigFlags(gastankAccountCmd)
+	gastankAccount := &gasTankAccountFlags{
+		chain:   gastankAccountCmd.Uint64("chain", 0, "Chain ID of the GasTank to use. Defaults to the origin chain."),
+		account: gastankAccountCmd.String("account", "gasProvider", "Account to act as: gasProvider, relayer, a hex private key or, for balance and withdrawal-status, an address."),
+		amount:  gastankAccountCmd.String("amount", "", "Amount in ETH, e.g. 0.005. Withdrawals default to the whole balance."),
+		to:      gastankAccountCmd.String("to", "", "Recipient of a deposit or finalized withdrawal. Defaults to the account."),
+	}
+
+	gasanalysisCmd := flag.NewFlagSet("gasanalysis", flag.ExitOnError)
+	gasanalysisConfig := addConfigFlags(gasanalysisCmd)
+	gasanalysisDepositTarget := gasanalysisCmd.String("depositTarget", "100%", "Gas provider balance to top up to before every run, a percentage of MAX_DEPOSIT (e.g. 50%) or an ETH amount.")
+	gasanalysisNested := gasanalysisCmd.String("nested", defaultNestedMessages, "Nested message counts to measure: a comma separated list of counts and ranges, e.g. 0,1,5-10 or 0-35:5.")
+	gasanalysisReps := gasanalysisCmd.Int("reps", 1, "Recorded runs per nested message count.")
+	gasanalysisWarmup := gasanalysisCmd.Int("warmup", 0, "Unrecorded warm-up runs per nested message count.")
+	gasanalysisOnFailure := gasanalysisCmd.String("onFailure", failurePolicyContinue, "What to do when a run fails: continue, retry (up to --retries times) or abort.")
+	gasanalysisRetries := gasanalysisCmd.Int("retries", 2, "Retries of a failed run with --onFailure retry.")
+	gasanalysisBaseline := gasanalysisCmd.String("baseline", "", "Results file to compare the run with, e.g. results/baseline.json. Exits non-zero on regressions.")
+	gasanalysisTolerance := gasanalysisCmd.Float64("tolerance", 50, "Growth of the mean relay or claim under-reimbursement, in gas units, tolerated against --baseline.")
+	gasanalysisFormat := gasanalysisCmd.String("format", "", "Comma separated output formats to save the results in besides the JSON file: csv (a row per sample) and markdown (the statistics table).")
+
+	calibrateCmd := flag.NewFlagSet("calibrate", flag.ExitOnError)
+	calibrateConfig := addConfigFlags(calibrateCmd)
+	calibrateNested := calibrateCmd.String("nested", "0-35:5", "Nested message counts to sweep, see gasanalysis --nested. Ignored when results files are given.")
+	calibrateReps := calibrateCmd.Int("reps", 3, "Recorded runs per nested message count.")
+	calibrateWarmup := calibrateCmd.Int("warmup", 1, "Unrecorded warm-up runs per nested message count.")
+	calibrateDepositTarget := calibrateCmd.String("depositTarget", "100%", "Gas provider balance to top up to before every run, see gasanalysis --depositTarget.")
+
+	gasplotCmd := flag.NewFlagSet("gasplot", flag.ExitOnError)
+	gasplotDir := gasplotCmd.String("dir", resultsDir(), "Directory holding the gas_analysis_*.json files, where the chart and report are written.")
+
+	exportCmd := flag.NewFlagSet("export", flag.ExitOnError)
+	exportFormat := exportCmd.String("format", formatCSV, "Comma separated output formats: json, csv (a row per sample) and markdown (the statistics table).")
+	exportOut := exportCmd.String("out", "", "Directory the converted files are written to. Defaults to the directory of every results file.")
+
+	relayerCmd := flag.NewFlagSet("relayer", flag.ExitOnError)
+	relayerConfig := addConfigFlags(relayerCmd)
+	pollInterval := relayerCmd.Duration("pollInterval", 2*time.Second, "Interval between two scans for new SentMessage logs.")
+	fromBlock := relayerCmd.Int64("fromBlock", -1, "First block to scan on every chain, -1 to start at the current head.")
+	gasProviders := relayerCmd.String("gasProviders", "", "Comma separated gas provider addresses whose authorized messages are relayed. Defaults to the configured gas provider.")
+	concurrency := relayerCmd.Int("concurrency", 32, "Maximum number of messages relayed and claimed at the same time.")
+
+	statusCmd := flag.NewFlagSet("status", flag.ExitOnError)
+	statusConfig := addConfigFlags(statusCmd)
+	statusJSON := statusCmd.Bool("json", false, "Print the status as JSON.")
+	statusFromBlock := statusCmd.Uint64("fromBlock", 0, "First block to scan for the message's logs on every chain.")
+	statusGasProviders := statusCmd.String("gasProviders", "", "Comma separated gas provider addresses whose authorizations are checked. Defaults to the configured gas provider.")
 
 	script := os.Args[1]
 	switch script {
 	case "relay":
-		tokenRelay()
+		relayCmd.Parse(os.Args[2:])
+		tokenRelay(mustLoadConfig(relayConfig, relayCmd))
 	case "gastank":
+		if command, args, ok := parseGasTankAccountCommand(os.Args[2:]); ok {
+			gastankAccountCmd.Parse(args)
+			if gastankAccountCmd.NArg() > 0 {
+				log.Fatalf("Usage: gastank %s [flags], unexpected arguments %q", command, gastankAccountCmd.Args())
+			}
+			cfg := mustLoadConfig(gastankAccountConfig, gastankAccountCmd)
+			if err := runGasTankAccountCommand(cfg, command, gastankAccount); err != nil {
+				log.Fatalf("gastank %s failed: %v", command, err)
+			}
+			return
+		}
 		gastankCmd.Parse(os.Args[2:])
-		_, _, err := gasTankRelay(*numNestedMessages, true)
+		// Anything left is a mistyped account command, which must not fall back to sending a relay
+		if gastankCmd.NArg() > 0 {
+			log.Fatalf("Usage: gastank [flags] or gastank %s [flags], unknown command %q", strings.Join(gasTankAccountCommands, "|"), strings.Join(gastankCmd.Args(), " "))
+		}
+		cfg := mustLoadConfig(gastankConfig, gastankCmd)
+		_, err := gasTankRelay(cfg, *numNestedMessages, mustParseDepositTarget(*gastankDepositTarget), *followNested, true)
 		if err != nil {
 			log.Fatalf("Gas tank relay failed: %v", err)
 		}
 	case "gasanalysis":
-		runGasAnalysis()
+		gasanalysisCmd.Parse(os.Args[2:])
+		cfg := mustLoadConfig(gasanalysisConfig, gasanalysisCmd)
+		nested, err := parseNestedMessages(*gasanalysisNested)
+		if err != nil {
+			log.Fatalf("Invalid --nested: %v", err)
+		}
+		var formats []string
+		if *gasanalysisFormat != "" {
+			if formats, err = parseOutputFormats(*gasanalysisFormat); err != nil {
+				log.Fatalf("Invalid --format: %v", err)
+			}
+		}
+		err = runGasAnalysis(cfg, gasAnalysisOptions{
+			NestedMessages: nested,
+			Repetitions:    *gasanalysisReps,
+			Warmup:         *gasanalysisWarmup,
+			OnFailure:      *gasanalysisOnFailure,
+			Retries:        *gasanalysisRetries,
+			DepositTarget:  mustParseDepositTarget(*gasanalysisDepositTarget),
+			Baseline:       *gasanalysisBaseline,
+			Tolerance:      *gasanalysisTolerance,
+			Formats:        formats,
+		})
+		if err != nil {
+			log.Fatalf("Gas analysis failed: %v", err)
+		}
+	case "calibrate":
+		calibrateCmd.Parse(os.Args[2:])
+		cfg := mustLoadConfig(calibrateConfig, calibrateCmd)
+		nested, err := parseNestedMessages(*calibrateNested)
+		if err != nil {
+			log.Fatalf("Invalid --nested: %v", err)
+		}
+		err = runCalibration(cfg, gasAnalysisOptions{
+			NestedMessages: nested,
+			Repetitions:    *calibrateReps,
+			Warmup:         *calibrateWarmup,
+			OnFailure:      failurePolicyContinue,
+			DepositTarget:  mustParseDepositTarget(*calibrateDepositTarget),
+		}, calibrateCmd.Args())
+		if err != nil {
+			log.Fatalf("Calibration failed: %v", err)
+		}
+	case "gasplot":
+		gasplotCmd.Parse(os.Args[2:])
+		if err := runGasPlot(*gasplotDir); err != nil {
+			log.Fatalf("Gas plot failed: %v", err)
+		}
+	case "export":
+		exportCmd.Parse(os.Args[2:])
+		formats, err := parseOutputFormats(*exportFormat)
+		if err != nil {
+			log.Fatalf("Invalid --format: %v", err)
+		}
+		if err := runExport(exportCmd.Args(), formats, *exportOut); err != nil {
+			log.Fatalf("Export failed: %v", err)
+		}
+	case "relayer":
+		relayerCmd.Parse(os.Args[2:])
+		cfg := mustLoadConfig(relayerConfig, relayerCmd)
+		providers, err := parseGasProviders(cfg, *gasProviders)
+		if err != nil {
+			log.Fatalf("Invalid gas providers: %v", err)
+		}
+		relayer, err := newRelayer(cfg, providers, *fromBlock, *pollInterval, *concurrency)
+		if err != nil {
+			log.Fatalf("Failed to start relayer: %v", err)
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := relayer.Run(ctx); err != nil {
+			log.Fatalf("Relayer failed: %v", err)
+		}
+	case "status":
+		statusCmd.Parse(os.Args[2:])
+		// Accept flags after the message hash as well
+		hashArg := statusCmd.Arg(0)
+		if statusCmd.NArg() > 1 {
+			statusCmd.Parse(statusCmd.Args()[1:])
+		}
+		var messageHash common.Hash
+		if err := messageHash.UnmarshalText([]byte(hashArg)); err != nil {
+			log.Fatalf("Usage: status <messageHash> [flags], invalid message hash %q: %v", hashArg, err)
+		}
+		cfg := mustLoadConfig(statusConfig, statusCmd)
+		providers, err := parseGasProviders(cfg, *statusGasProviders)
+		if err != nil {
+			log.Fatalf("Invalid gas providers: %v", err)
+		}
+		status, err := inspectMessage(context.Background(), cfg, messageHash, providers, *statusFromBlock)
+		if err != nil {
+			log.Fatalf("Failed to inspect message: %v", err)
+		}
+		if *statusJSON {
+			if err := status.writeJSON(os.Stdout); err != nil {
+				log.Fatalf("Failed to print status: %v", err)
+			}
+		} else {
+			status.writeText(os.Stdout)
+		}
 	default:
 		fmt.Printf("Unknown script: %s\n", script)
 		os.Exit(1)
 	}
 }
+
+func mustLoadConfig(flags *configFlags, fs *flag.FlagSet) *Config {
+	cfg, err := flags.load(fs)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if cfg.MockAdmin {
+		// The server lives as long as the process
+		server, err := mockadmin.Start("127.0.0.1:0")
+		if err != nil {
+			log.Fatalf("Failed to start the mock admin RPC: %v", err)
+		}
+		cfg.AdminRPC = server.URL()
+		log.Printf("Serving access lists from the mock admin RPC at %s", cfg.AdminRPC)
+	}
+	return cfg
+}
+
+func mustParseDepositTarget(target string) depositTarget {
+	parsed, err := parseDepositTarget(target)
+	if err != nil {
+		log.Fatalf("Invalid deposit target: %v", err)
+	}
+	return parsed
+}
+
+// parseGasProviders parses a comma separated list of addresses, defaulting to the configured gas provider
+func parseGasProviders(cfg *Config, list string) ([]common.Address, error) {
+	if list == "" {
+		key, err := cfg.GasProvider()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load gas provider private key: %w", err)
+		}
+		return []common.Address{crypto.PubkeyToAddress(*key.Public().(*ecdsa.PublicKey))}, nil
+	}
+
+	var providers []common.Address
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if !common.IsHexAddress(entry) {
+			return nil, fmt.Errorf("%q is not an address", entry)
+		}
+		providers = append(providers, common.HexToAddress(entry))
+	}
+	return providers, nil
+}
@@ -0,0 +1,198 @@
+// This file follows the tree of nested messages produced by a GasTank relay: every SentMessage emitted
+// while relaying a message is relayed on its own destination and claimed in turn.
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"supersim-e2e-example/interop"
+)
+
+// maxMessageTreeDepth stops the recursion on message trees that keep bouncing between chains
+const maxMessageTreeDepth = 8
+
+// messageNode is a relayed message together with the messages its relay produced
+type messageNode struct {
+	Hash        common.Hash
+	Source      uint64
+	Destination uint64
+	// ClaimChain is the chain whose GasTank repaid the relay
+	ClaimChain   uint64
+	RelayTx      common.Hash
+	RelayGasUsed uint64
+	RelayCost    *big.Int
+	ClaimTx      common.Hash
+	ClaimGasUsed uint64
+	ClaimCost    *big.Int
+	Err          error
+	Children     []*messageNode
+}
+
+// messageTreeRelayer relays and claims the nested messages of a message tree
+type messageTreeRelayer struct {
+	ctx         context.Context
+	cfg         *Config
+	relayerKey  *ecdsa.PrivateKey
+	gasProvider common.Address
+	chains      map[uint64]*gasTankChain
+	verbose     bool
+}
+
+// relayNested relays and claims every message sent by the relay transaction of parent, recursing into the
+// messages produced by those relays. parentClaimChain is the chain on which parent was claimed.
+func (t *messageTreeRelayer) relayNested(parent *messageNode, relayTx *types.Receipt, parentClaimChain uint64, depth int) {
+	if depth >= maxMessageTreeDepth {
+		return
+	}
+	relayChain := t.chains[parent.Destination]
+
+	for _, sentLog := range relayTx.Logs {
+		if sentLog.Address != l2CrossDomainMessengerAddr || len(sentLog.Topics) != 4 || sentLog.Topics[0] != interop.SentMessageTopic {
+			continue
+		}
+		node := &messageNode{
+			Source:      relayChain.ChainID,
+			Destination: new(big.Int).SetBytes(sentLog.Topics[1].Bytes()).Uint64(),
+		}
+		parent.Children = append(parent.Children, node)
+
+		nestedRelayTx, err := t.relayAndClaim(node, relayChain, sentLog, parentClaimChain)
+		if err != nil {
+			node.Err = err
+			logfIf(t.verbose, "Nested message %s failed: %v\n", node.Hash.Hex(), err)
+			continue
+		}
+		t.relayNested(node, nestedRelayTx, node.ClaimChain, depth+1)
+	}
+}
+
+// relayAndClaim relays a single nested message and claims it, filling in node as it progresses
+func (t *messageTreeRelayer) relayAndClaim(node *messageNode, source *gasTankChain, sentLog *types.Log, parentClaimChain uint64) (*types.Receipt, error) {
+	hash, err := sentMessageHash(source.ID(), sentLog)
+	if err != nil {
+		return nil, err
+	}
+	node.Hash = hash
+
+	destination, ok := t.chains[node.Destination]
+	if !ok {
+		return nil, fmt.Errorf("destination chain %d is not configured", node.Destination)
+	}
+
+	relayTx, receiptLog, err := relayThroughGasTank(t.ctx, t.cfg, t.relayerKey, source, destination, sentLog)
+	if err != nil {
+		return nil, err
+	}
+	node.RelayTx = relayTx.TxHash
+	node.RelayGasUsed = relayTx.GasUsed
+	gasReceipt, err := interop.GasReceiptFromLog(receiptLog)
+	if err != nil {
+		return nil, err
+	}
+	node.RelayCost = gasReceipt.RelayCost
+	logfIf(t.verbose, "Relayed nested message %s on chain %d: %s\n", hash.Hex(), destination.ChainID, relayTx.TxHash.Hex())
+
+	// GasTank.claim authorizes the nested hashes in the GasTank it runs on, which is the chain the parent was
+	// claimed on rather than the nested message's origin. Claim wherever the gas provider's authorization lives,
+	// preferring the origin when the gas provider authorized the message there explicitly.
+	claimChain, err := t.authorizedClaimChain(hash, source.ChainID, parentClaimChain)
+	if err != nil {
+		return relayTx, err
+	}
+	node.ClaimChain = claimChain.ChainID
+
+	claimTx, err := claimFromGasTank(t.ctx, t.cfg, t.relayerKey, destination, claimChain, t.gasProvider, receiptLog)
+	if err != nil {
+		return relayTx, err
+	}
+	node.ClaimTx = claimTx.TxHash
+	node.ClaimGasUsed = claimTx.GasUsed
+	claimedLog := findLog(claimTx.Logs, claimChain.gasTank, claimedTopic)
+	if claimedLog == nil {
+		return relayTx, fmt.Errorf("could not find Claimed event in claim transaction %s", claimTx.TxHash.Hex())
+	}
+	claimed, err := gasTankContract.UnpackClaimedEvent(claimedLog)
+	if err != nil {
+		return relayTx, fmt.Errorf("failed to unpack Claimed event data: %w", err)
+	}
+	node.ClaimCost = claimed.ClaimCost
+	logfIf(t.verbose, "Claimed nested message %s on chain %d: %s\n", hash.Hex(), claimChain.ChainID, claimTx.TxHash.Hex())
+
+	return relayTx, nil
+}
+
+// authorizedClaimChain returns the first of the candidate chains whose GasTank authorizes the gas provider's claim
+func (t *messageTreeRelayer) authorizedClaimChain(hash common.Hash, candidates ...uint64) (*gasTankChain, error) {
+	chain, err := authorizedClaimChain(t.ctx, t.chains, t.gasProvider, hash, candidates...)
+	if err != nil {
+		return nil, err
+	}
+	if chain == nil {
+		return nil, fmt.Errorf("message is not authorized by gas provider %s on chains %v", t.gasProvider.Hex(), candidates)
+	}
+	return chain, nil
+}
+
+// authorizedClaimChain returns the first of the candidate chains whose GasTank authorizes the gas provider's claim,
+// or nil when none does
+func authorizedClaimChain(ctx context.Context, chains map[uint64]*gasTankChain, gasProvider common.Address, hash common.Hash, candidates ...uint64) (*gasTankChain, error) {
+	for _, chainID := range candidates {
+		chain, ok := chains[chainID]
+		if !ok {
+			continue
+		}
+		authorized, err := callView(ctx, chain.client, chain.gasTank, gasTankContract.PackAuthorizedMessages(gasProvider, hash), gasTankContract.UnpackAuthorizedMessages)
+		if err != nil {
+			return nil, err
+		}
+		if authorized {
+			return chain, nil
+		}
+	}
+	return nil, nil
+}
+
+// printMessageTree prints every node of the tree with its relay and claim costs, followed by the totals
+func printMessageTree(root *messageNode) {
+	fmt.Println("\n--- Message Tree ---")
+	totalRelay, totalClaim := new(big.Int), new(big.Int)
+	var walk func(node *messageNode, prefix string, last bool, depth int)
+	walk = func(node *messageNode, prefix string, last bool, depth int) {
+		branch, childPrefix := "├─ ", prefix+"│  "
+		if last {
+			branch, childPrefix = "└─ ", prefix+"   "
+		}
+		if depth == 0 {
+			branch, childPrefix = "", ""
+		}
+		fmt.Printf("%s%s%s %d → %d\n", prefix, branch, node.Hash.Hex(), node.Source, node.Destination)
+
+		detailPrefix := childPrefix
+		if node.RelayCost != nil {
+			fmt.Printf("%s  relay: %d gas used, %s wei declared (tx %s)\n", detailPrefix, node.RelayGasUsed, node.RelayCost.String(), node.RelayTx.Hex())
+			totalRelay.Add(totalRelay, node.RelayCost)
+		}
+		if node.ClaimCost != nil {
+			fmt.Printf("%s  claim on %d: %d gas used, %s wei declared (tx %s)\n", detailPrefix, node.ClaimChain, node.ClaimGasUsed, node.ClaimCost.String(), node.ClaimTx.Hex())
+			totalClaim.Add(totalClaim, node.ClaimCost)
+		}
+		if node.Err != nil {
+			fmt.Printf("%s  \033[31merror: %v\033[0m\n", detailPrefix, strings.TrimSpace(node.Err.Error()))
+		}
+		for i, child := range node.Children {
+			walk(child, childPrefix, i == len(node.Children)-1, depth+1)
+		}
+	}
+	walk(root, "", true, 0)
+
+	fmt.Printf("\nTotal relay cost: %s wei\n", totalRelay.String())
+	fmt.Printf("Total claim cost: %s wei\n", totalClaim.String())
+	fmt.Printf("Total gas provider cost: %s wei\n", new(big.Int).Add(totalRelay, totalClaim).String())
+}
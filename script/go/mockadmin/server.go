@@ -0,0 +1,79 @@
+// Package mockadmin is an in-process stand-in for supersim's admin RPC. It serves
+// admin_getAccessListForIdentifier, computing the CrossL2Inbox access list from the Identifier and payload
+// instead of asking supersim, so the scripts and tests can run without a supersim instance.
+package mockadmin
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"supersim-e2e-example/interop"
+)
+
+// GetAccessListForIdentifierRequest is the parameter of admin_getAccessListForIdentifier
+type GetAccessListForIdentifierRequest struct {
+	interop.Identifier
+	Payload hexutil.Bytes `json:"payload"`
+}
+
+// GetAccessListResponse is the result of admin_getAccessListForIdentifier
+type GetAccessListResponse struct {
+	AccessList types.AccessList `json:"accessList"`
+}
+
+// adminAPI is registered under the admin namespace, its methods are served as admin_<method>
+type adminAPI struct{}
+
+// GetAccessListForIdentifier returns the access list a transaction executing the identified message must carry
+func (adminAPI) GetAccessListForIdentifier(req GetAccessListForIdentifierRequest) (*GetAccessListResponse, error) {
+	accessList, err := interop.NewAccessList(req.Identifier, req.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &GetAccessListResponse{AccessList: accessList}, nil
+}
+
+// Server serves the mock admin RPC over HTTP until it is closed
+type Server struct {
+	rpc      *rpc.Server
+	http     *http.Server
+	listener net.Listener
+}
+
+// Start serves the mock admin RPC on addr, e.g. "127.0.0.1:8420" or "127.0.0.1:0" for any free port
+func Start(addr string) (*Server, error) {
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName("admin", adminAPI{}); err != nil {
+		return nil, fmt.Errorf("failed to register the admin API: %w", err)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		rpcServer.Stop()
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s := &Server{rpc: rpcServer, http: &http.Server{Handler: rpcServer}, listener: listener}
+	go func() {
+		if err := s.http.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			rpcServer.Stop()
+		}
+	}()
+	return s, nil
+}
+
+// URL returns the HTTP endpoint of the server, to use as the admin RPC URL
+func (s *Server) URL() string {
+	return "http://" + s.listener.Addr().String()
+}
+
+// Close stops serving and closes the listener
+func (s *Server) Close() error {
+	defer s.rpc.Stop()
+	return s.http.Close()
+}
@@ -0,0 +1,82 @@
+package mockadmin
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"supersim-e2e-example/interop"
+)
+
+// TestGetAccessListForIdentifier calls the server the way getAccessList calls supersim, with the payload as a
+// hex string next to the Identifier fields.
+func TestGetAccessListForIdentifier(t *testing.T) {
+	server, err := Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer server.Close()
+
+	client, err := rpc.Dial(server.URL())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer client.Close()
+
+	req := struct {
+		interop.Identifier
+		Payload string `json:"payload"`
+	}{
+		Identifier: interop.Identifier{
+			Origin:      common.HexToAddress("0x4200000000000000000000000000000000000023"),
+			BlockNumber: big.NewInt(12345),
+			LogIndex:    big.NewInt(2),
+			Timestamp:   big.NewInt(1700000000),
+			ChainID:     big.NewInt(901),
+		},
+		Payload: "0x382409ac69001e11931a28435afef442cbfd20d9891907e8fa373ba7d351f3200000000000000000000000000000000000000000000000000000000000000384",
+	}
+	var result struct {
+		AccessList types.AccessList `json:"accessList"`
+	}
+	if err := client.CallContext(context.Background(), &result, "admin_getAccessListForIdentifier", req); err != nil {
+		t.Fatalf("admin_getAccessListForIdentifier: %v", err)
+	}
+
+	want := []common.Hash{
+		common.HexToHash("0x0100000000000000000003850000000000003039000000006553f10000000002"),
+		common.HexToHash("0x0311eaaf9702acfeac799f42a5bcc10582603152b17ea49876a15ad8ec95b991"),
+	}
+	if len(result.AccessList) != 1 || result.AccessList[0].Address != interop.CrossL2InboxAddress {
+		t.Fatalf("access list = %v, want a single CrossL2Inbox entry", result.AccessList)
+	}
+	if !slices.Equal(result.AccessList[0].StorageKeys, want) {
+		t.Errorf("storage keys = %v, want %v", result.AccessList[0].StorageKeys, want)
+	}
+}
+
+func TestGetAccessListForIdentifierInvalid(t *testing.T) {
+	server, err := Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer server.Close()
+
+	client, err := rpc.Dial(server.URL())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer client.Close()
+
+	// The Identifier fields are missing
+	req := map[string]string{"payload": "0x"}
+	var result GetAccessListResponse
+	if err := client.CallContext(context.Background(), &result, "admin_getAccessListForIdentifier", req); err == nil {
+		t.Error("admin_getAccessListForIdentifier succeeded, want an error")
+	}
+}
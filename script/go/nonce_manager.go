@@ -0,0 +1,114 @@
+// This file contains the nonce manager handing out the nonces of the transactions sent by every TxSender,
+// so that an account can have many transactions in flight without querying the node for each of them.
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// nonceBackend is the subset of ethclient.Client a nonceManager needs
+type nonceBackend interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// nonceManager hands out the nonces of one account on the chain behind its backend
+type nonceManager struct {
+	mu      sync.Mutex
+	backend nonceBackend
+	account common.Address
+	synced  bool
+	// next is the nonce after the highest one handed out
+	next uint64
+	// released are nonces below next whose transaction never made it to the chain. They are handed out again
+	// first, lowest first, as the transactions above them cannot be mined until the gap is filled.
+	released []uint64
+}
+
+// newNonceManager returns the nonce manager of an account, synced with backend on first use. Every account
+// sending on a connection needs exactly one: gasTankChain.sender caches the senders, and with them their
+// nonce managers, per account.
+func newNonceManager(backend nonceBackend, account common.Address) *nonceManager {
+	return &nonceManager{backend: backend, account: account}
+}
+
+// Next hands out a nonce, syncing with the node's pending nonce on first use
+func (m *nonceManager) Next(ctx context.Context) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.synced {
+		if err := m.resyncLocked(ctx); err != nil {
+			return 0, err
+		}
+	}
+	if len(m.released) > 0 {
+		nonce := m.released[0]
+		m.released = m.released[1:]
+		return nonce, nil
+	}
+	nonce := m.next
+	m.next++
+	return nonce, nil
+}
+
+// Release returns a nonce whose transaction was not sent or was dropped, so that the next transaction fills the gap
+func (m *nonceManager) Release(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if nonce >= m.next {
+		return
+	}
+	for _, released := range m.released {
+		if released == nonce {
+			return
+		}
+	}
+	m.released = append(m.released, nonce)
+	sort.Slice(m.released, func(i, j int) bool { return m.released[i] < m.released[j] })
+}
+
+// Resync resets the manager to the node's pending nonce, after a nonce was reported as too low
+func (m *nonceManager) Resync(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.resyncLocked(ctx)
+}
+
+func (m *nonceManager) resyncLocked(ctx context.Context) error {
+	pending, err := m.backend.PendingNonceAt(ctx, m.account)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce of %s: %w", m.account.Hex(), err)
+	}
+	// Keep the nonces handed out above the pending nonce, they belong to transactions still being sent
+	if !m.synced || pending > m.next {
+		m.next = pending
+	}
+	released := m.released[:0]
+	for _, nonce := range m.released {
+		if nonce >= pending {
+			released = append(released, nonce)
+		}
+	}
+	m.released = released
+	m.synced = true
+	return nil
+}
+
+// isNonceTooLow reports whether the node rejected a transaction because its nonce was already used. The error
+// crosses the RPC boundary as a message only.
+func isNonceTooLow(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
+
+// isAlreadyKnown reports whether the node rejected a transaction because this exact transaction is already in
+// its pool. It is not a nonce conflict: re-signing it with another nonce would send the call twice.
+func isAlreadyKnown(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "already known")
+}
@@ -12,49 +12,60 @@ import (
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+
+	"supersim-e2e-example/interop"
 )
 
-func tokenRelay() {
+func tokenRelay(cfg *Config) {
 	fmt.Println("Starting end-to-end manual relay script...")
 
 	// === Setup Clients and Signer ===
-	client901, err := ethclient.Dial("http://127.0.0.1:9545")
+	originClient, err := cfg.Origin.Dial()
 	if err != nil {
-		log.Fatalf("Failed to connect to the source chain (901): %v", err)
+		log.Fatalf("Failed to connect to the source chain (%d): %v", cfg.Origin.ChainID, err)
 	}
-	client902, err := ethclient.Dial("http://127.0.0.1:9546")
+	destinationClient, err := cfg.Destination.Dial()
 	if err != nil {
-		log.Fatalf("Failed to connect to the destination chain (902): %v", err)
+		log.Fatalf("Failed to connect to the destination chain (%d): %v", cfg.Destination.ChainID, err)
 	}
-	privateKey, err := crypto.HexToECDSA("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	privateKey, err := cfg.GasProvider()
 	if err != nil {
 		log.Fatalf("Failed to load private key: %v", err)
 	}
 	fromAddress := crypto.PubkeyToAddress(*privateKey.Public().(*ecdsa.PublicKey))
 	fmt.Printf("Using address: %s\n", fromAddress.Hex())
 
-	// === Step 1: Mint tokens on Chain 901 ===
-	fmt.Println("\n=== Step 1: Minting tokens on Chain 901 ===")
+	ctx := context.Background()
+	originSender, err := NewTxSender(originClient, cfg.Origin.ID(), privateKey, cfg.Tx)
+	if err != nil {
+		log.Fatalf("Failed to create origin transaction sender: %v", err)
+	}
+	destinationSender, err := NewTxSender(destinationClient, cfg.Destination.ID(), privateKey, cfg.Tx)
+	if err != nil {
+		log.Fatalf("Failed to create destination transaction sender: %v", err)
+	}
+
+	// === Step 1: Mint tokens on the origin chain ===
+	fmt.Printf("\n=== Step 1: Minting tokens on Chain %d ===\n", cfg.Origin.ChainID)
 	mintAmount := big.NewInt(1000)
 	mintCalldata, err := tokenABI.Pack("mint", fromAddress, mintAmount)
 	if err != nil {
 		log.Fatalf("Failed to pack mint ABI: %v", err)
 	}
-	mintTx, err := sendAndWaitForTransaction(client901, big.NewInt(901), privateKey, &l2TokenAddr, big.NewInt(0), mintCalldata)
+	mintTx, err := originSender.Send(ctx, &l2TokenAddr, big.NewInt(0), mintCalldata)
 	if err != nil {
 		log.Fatalf("Mint transaction failed: %v", err)
 	}
 	fmt.Printf("Mint transaction successful: %s\n", mintTx.TxHash.Hex())
 
-	// === Step 2: Send cross-chain message from 901 to 902 ===
-	fmt.Println("\n=== Step 2: Sending cross-chain message from 901 to 902 ===")
-	destChainID := big.NewInt(902)
+	// === Step 2: Send cross-chain message from origin to destination ===
+	fmt.Printf("\n=== Step 2: Sending cross-chain message from %d to %d ===\n", cfg.Origin.ChainID, cfg.Destination.ChainID)
+	destChainID := cfg.Destination.ID()
 	sendCalldata, err := bridgeABI.Pack("sendERC20", l2TokenAddr, fromAddress, mintAmount, destChainID)
 	if err != nil {
 		log.Fatalf("Failed to pack sendERC20 ABI: %v", err)
 	}
-	sendTx, err := sendAndWaitForTransaction(client901, big.NewInt(901), privateKey, &superchainTokenBridgeAddr, big.NewInt(0), sendCalldata)
+	sendTx, err := originSender.Send(ctx, &superchainTokenBridgeAddr, big.NewInt(0), sendCalldata)
 	if err != nil {
 		log.Fatalf("Send ERC20 transaction failed: %v", err)
 	}
@@ -65,7 +76,7 @@ func tokenRelay() {
 	var sentMessageLog types.Log
 	found := false
 	for _, logEntry := range sendTx.Logs {
-		if logEntry.Address == l2CrossDomainMessengerAddr && len(logEntry.Topics) > 0 && logEntry.Topics[0] == sentMessageTopic {
+		if logEntry.Address == l2CrossDomainMessengerAddr && len(logEntry.Topics) > 0 && logEntry.Topics[0] == interop.SentMessageTopic {
 			sentMessageLog = *logEntry
 			found = true
 			break
@@ -78,7 +89,7 @@ func tokenRelay() {
 
 	// === Step 4: Retrieve block info for the log ===
 	fmt.Println("\n=== Step 4: Retrieving block info ===")
-	block, err := client901.BlockByHash(context.Background(), sendTx.BlockHash)
+	block, err := originClient.BlockByHash(context.Background(), sendTx.BlockHash)
 	if err != nil {
 		log.Fatalf("failed to get block by hash: %v", err)
 	}
@@ -87,37 +98,27 @@ func tokenRelay() {
 
 	// === Step 5: Prepare message identifier & payload ===
 	fmt.Println("\n=== Step 5: Preparing identifier and payload ===")
-	identifier := Identifier{
-		Origin:      l2CrossDomainMessengerAddr,
-		BlockNumber: new(big.Int).SetUint64(sentMessageLog.BlockNumber),
-		LogIndex:    big.NewInt(int64(sentMessageLog.Index)),
-		Timestamp:   new(big.Int).SetUint64(timestamp),
-		ChainID:     big.NewInt(901),
-	}
-	var payload []byte
-	for _, topic := range sentMessageLog.Topics {
-		payload = append(payload, topic.Bytes()...)
-	}
-	payload = append(payload, sentMessageLog.Data...)
+	identifier := interop.NewIdentifier(&sentMessageLog, block.Header(), cfg.Origin.ID())
+	payload := interop.LogPayload(&sentMessageLog)
 	fmt.Printf("Constructed Identifier: %+v\n", identifier)
 	fmt.Printf("Successfully retrieved sent message payload: %s\n", hex.EncodeToString(payload))
 
-	// === Step 6: Get the access list via admin RPC ===
-	fmt.Println("\n=== Step 6: Retrieving access list from supersim ===")
-	accessList, err := getAccessList(identifier, payload)
+	// === Step 6: Get the access list ===
+	fmt.Println("\n=== Step 6: Retrieving access list ===")
+	accessList, err := getAccessList(cfg, identifier, payload)
 	if err != nil {
 		log.Fatalf("Failed to get access list: %v", err)
 	}
+	if err := verifyAccessList(ctx, originClient, destinationClient, identifier, payload, *accessList); err != nil {
+		log.Fatalf("Invalid access list: %v", err)
+	}
 	fmt.Printf("Successfully retrieved access list with %d entries\n", len(*accessList))
 
 	// === Step 7: Relay the message on L2 ===
 	fmt.Println("\n=== Step 7: Relaying message on L2 ===")
-	relayCalldata, err := crossDomainMessengerABI.Pack("relayMessage", identifier, payload)
-	if err != nil {
-		log.Fatalf("Failed to pack relayMessage ABI: %v", err)
-	}
+	relayCalldata := messengerContract.PackRelayMessage(bindingIdentifier(identifier), payload)
 
-	relayTx, err := sendAndWaitForTransaction(client902, destChainID, privateKey, &l2CrossDomainMessengerAddr, big.NewInt(0), relayCalldata, *accessList)
+	relayTx, err := destinationSender.Send(ctx, &l2CrossDomainMessengerAddr, big.NewInt(0), relayCalldata, *accessList)
 	if err != nil {
 		log.Fatalf("Relay transaction failed: %v", err)
 	}
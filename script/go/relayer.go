@@ -0,0 +1,404 @@
+// This script runs a long-lived relayer that watches SentMessage and AuthorizedClaims events on every
+// configured chain, relays the messages authorized by a gas provider through the GasTank on their destination
+// and claims the repayment on the chain holding the authorization.
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"supersim-e2e-example/bindings"
+	"supersim-e2e-example/interop"
+)
+
+// maxRelayAttempts bounds how many times a failing relay or claim is retried before the message is dropped
+const maxRelayAttempts = 3
+
+// unmatchedMessageTTL is how long a SentMessage waits for its AuthorizedClaims log, or an authorization for its
+// SentMessage, before it is forgotten. Most messages are never authorized by one of the gas providers.
+const unmatchedMessageTTL = 30 * time.Minute
+
+// maxScanBlocks bounds the block range of a single scan, which stays within the log limits of RPC providers.
+// A chain further behind catches up over the next polls.
+const maxScanBlocks = 2000
+
+// relayerChain tracks the scanning progress on a single configured chain
+type relayerChain struct {
+	*gasTankChain
+	// nextBlock is the first block that has not been scanned for SentMessage and AuthorizedClaims logs yet
+	nextBlock uint64
+}
+
+// pendingMessage is a message that has not been relayed and claimed yet. It is processed once both its
+// SentMessage log and an AuthorizedClaims log listing it have been seen, in whichever order they arrive.
+type pendingMessage struct {
+	hash common.Hash
+	// source, destination and sentLog are set once the SentMessage log was seen
+	source      *relayerChain
+	destination *relayerChain
+	sentLog     *types.Log
+	// authorizedOn and gasProvider are set once an AuthorizedClaims log listing the message was seen
+	authorizedOn uint64
+	gasProvider  common.Address
+	// expiresAt is when the message is forgotten if it is still missing one of the two logs
+	expiresAt time.Time
+	// gasReceiptLog is set once the relay succeeded and only the claim is left
+	gasReceiptLog *types.Log
+	// claimChain is the chain whose GasTank holds the gas provider's authorization: the origin for messages
+	// authorized with authorizeClaim, the chain their parent was claimed on for nested messages
+	claimChain *gasTankChain
+	attempts   int
+}
+
+// ready reports whether both the SentMessage and the authorization of the message have been seen
+func (m *pendingMessage) ready() bool {
+	return m.sentLog != nil && m.authorizedOn != 0
+}
+
+// Relayer relays and claims every message authorized by one of its gas providers
+type Relayer struct {
+	cfg            *Config
+	chains         map[uint64]*relayerChain
+	gasTanks       map[uint64]*gasTankChain
+	relayerKey     *ecdsa.PrivateKey
+	relayerAddress common.Address
+	gasProviders   []common.Address
+	pollInterval   time.Duration
+	// concurrency bounds how many pending messages are processed at the same time
+	concurrency int
+	pending     map[common.Hash]*pendingMessage
+}
+
+// newRelayer connects to every configured chain. A negative fromBlock starts scanning at the current head.
+func newRelayer(cfg *Config, gasProviders []common.Address, fromBlock int64, pollInterval time.Duration, concurrency int) (*Relayer, error) {
+	if concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1, got %d", concurrency)
+	}
+	relayerKey, err := cfg.Relayer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load relayer private key: %w", err)
+	}
+	contracts, err := loadSupersimContracts(cfg.ContractsFile)
+	if err != nil {
+		return nil, err
+	}
+	chains, err := dialGasTankChains(cfg, contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Relayer{
+		cfg:            cfg,
+		chains:         make(map[uint64]*relayerChain),
+		gasTanks:       chains,
+		relayerKey:     relayerKey,
+		relayerAddress: crypto.PubkeyToAddress(*relayerKey.Public().(*ecdsa.PublicKey)),
+		gasProviders:   gasProviders,
+		pollInterval:   pollInterval,
+		concurrency:    concurrency,
+		pending:        make(map[common.Hash]*pendingMessage),
+	}
+	for chainID, chain := range chains {
+		start := uint64(fromBlock)
+		if fromBlock < 0 {
+			start, err = chain.client.BlockNumber(context.Background())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get head of chain %d: %w", chainID, err)
+			}
+		}
+		r.chains[chainID] = &relayerChain{gasTankChain: chain, nextBlock: start}
+	}
+	return r, nil
+}
+
+// Run polls every chain until the context is cancelled
+func (r *Relayer) Run(ctx context.Context) error {
+	log.Printf("Relayer %s watching %d chains for messages authorized by %v", r.relayerAddress.Hex(), len(r.chains), r.gasProviders)
+
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	for {
+		r.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Printf("Relayer stopped with %d pending messages", len(r.pending))
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll scans every chain for new logs and processes the pending messages that are ready
+func (r *Relayer) poll(ctx context.Context) {
+	for _, chainID := range r.chainIDs() {
+		if err := r.scan(ctx, r.chains[chainID]); err != nil {
+			log.Printf("Failed to scan chain %d: %v", chainID, err)
+		}
+	}
+
+	now := time.Now()
+	var ready []*pendingMessage
+	for hash, msg := range r.pending {
+		switch {
+		case msg.ready():
+			ready = append(ready, msg)
+		case now.After(msg.expiresAt):
+			if msg.authorizedOn != 0 {
+				log.Printf("Dropping message %s, authorized on chain %d but its SentMessage was not seen", hash.Hex(), msg.authorizedOn)
+			}
+			delete(r.pending, hash)
+		}
+	}
+
+	// Process the pending messages concurrently: the relayer's nonces are handed out locally, so its
+	// transactions on a chain are pipelined instead of being sent one block after the other
+	type result struct {
+		hash common.Hash
+		done bool
+		err  error
+	}
+	results := make(chan result, len(ready))
+	slots := make(chan struct{}, r.concurrency)
+	var wg sync.WaitGroup
+	for _, msg := range ready {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			if ctx.Err() != nil {
+				return
+			}
+			done, err := r.process(ctx, msg)
+			results <- result{hash: msg.hash, done: done, err: err}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	for res := range results {
+		msg := r.pending[res.hash]
+		done := res.done
+		if res.err != nil {
+			msg.attempts++
+			log.Printf("Message %s (attempt %d/%d) failed: %v", res.hash.Hex(), msg.attempts, maxRelayAttempts, res.err)
+			if msg.attempts >= maxRelayAttempts {
+				log.Printf("Dropping message %s", res.hash.Hex())
+				done = true
+			}
+		}
+		if done {
+			delete(r.pending, res.hash)
+		}
+	}
+}
+
+// scan fetches the SentMessage and AuthorizedClaims logs emitted since the last scan, up to maxScanBlocks blocks,
+// and queues them. Logs that cannot be decoded are skipped so that the scan always moves past them.
+func (r *Relayer) scan(ctx context.Context, chain *relayerChain) error {
+	head, err := chain.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %w", err)
+	}
+	if head < chain.nextBlock {
+		return nil
+	}
+	toBlock := min(head, chain.nextBlock+maxScanBlocks-1)
+
+	logs, err := chain.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(chain.nextBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{l2CrossDomainMessengerAddr},
+		Topics:    [][]common.Hash{{interop.SentMessageTopic}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to filter SentMessage logs in blocks %d-%d: %w", chain.nextBlock, toBlock, err)
+	}
+
+	for _, sentLog := range logs {
+		if sentLog.Removed || len(sentLog.Topics) != 4 {
+			continue
+		}
+		destinationID := new(big.Int).SetBytes(sentLog.Topics[1].Bytes())
+		destination, ok := r.chains[destinationID.Uint64()]
+		if !destinationID.IsUint64() || !ok {
+			continue
+		}
+
+		hash, err := sentMessageHash(chain.ID(), &sentLog)
+		if err != nil {
+			log.Printf("Skipping SentMessage log %d of tx %s on chain %d: %v", sentLog.Index, sentLog.TxHash.Hex(), chain.ChainID, err)
+			continue
+		}
+		msg := r.pendingMessage(hash)
+		if msg.sentLog != nil {
+			continue
+		}
+		msg.source, msg.destination, msg.sentLog = chain, destination, &sentLog
+		if msg.ready() {
+			log.Printf("Found message %s from chain %d to chain %d", hash.Hex(), chain.ChainID, destination.ChainID)
+		}
+	}
+
+	// The gas provider is the only indexed field of AuthorizedClaims, the hashes are in the data
+	gasProviderTopics := make([]common.Hash, len(r.gasProviders))
+	for i, gasProvider := range r.gasProviders {
+		gasProviderTopics[i] = common.BytesToHash(gasProvider.Bytes())
+	}
+	authLogs, err := chain.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(chain.nextBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{chain.gasTank},
+		Topics:    [][]common.Hash{{authorizedClaimsTopic}, gasProviderTopics},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to filter AuthorizedClaims logs in blocks %d-%d: %w", chain.nextBlock, toBlock, err)
+	}
+
+	for _, authLog := range authLogs {
+		if authLog.Removed {
+			continue
+		}
+		event, err := gasTankContract.UnpackAuthorizedClaimsEvent(&authLog)
+		if err != nil {
+			log.Printf("Skipping AuthorizedClaims log %d of tx %s on chain %d: %v", authLog.Index, authLog.TxHash.Hex(), chain.ChainID, err)
+			continue
+		}
+		for _, hash := range event.MessageHashes {
+			msg := r.pendingMessage(hash)
+			if msg.authorizedOn != 0 {
+				continue
+			}
+			msg.authorizedOn, msg.gasProvider = chain.ChainID, event.GasProvider
+			if msg.ready() {
+				log.Printf("Found message %s from chain %d to chain %d", msg.hash.Hex(), msg.source.ChainID, msg.destination.ChainID)
+			}
+		}
+	}
+
+	chain.nextBlock = toBlock + 1
+	return nil
+}
+
+// pendingMessage returns the queued message with the given hash, queueing it if it is new
+func (r *Relayer) pendingMessage(hash common.Hash) *pendingMessage {
+	msg, ok := r.pending[hash]
+	if !ok {
+		msg = &pendingMessage{hash: hash, expiresAt: time.Now().Add(unmatchedMessageTTL)}
+		r.pending[hash] = msg
+	}
+	return msg
+}
+
+// process advances a pending message and reports whether it can be removed from the queue
+func (r *Relayer) process(ctx context.Context, msg *pendingMessage) (bool, error) {
+	if msg.gasReceiptLog == nil {
+		relayed, err := callView(ctx, msg.destination.client, l2CrossDomainMessengerAddr, messengerContract.PackSuccessfulMessages(msg.hash), messengerContract.UnpackSuccessfulMessages)
+		if err != nil {
+			return false, err
+		}
+		if relayed {
+			log.Printf("Message %s was already relayed by someone else, skipping", msg.hash.Hex())
+			return true, nil
+		}
+
+		if msg.claimChain == nil {
+			claimChain, err := r.authorizedClaimChain(ctx, msg)
+			if err != nil {
+				return false, err
+			}
+			msg.claimChain = claimChain
+		}
+
+		if err := r.relay(ctx, msg); err != nil {
+			var alreadyRelayed *bindings.L2ToL2CrossDomainMessengerMessageAlreadyRelayed
+			if errors.As(err, &alreadyRelayed) {
+				log.Printf("Message %s was relayed by someone else in the meantime, skipping", msg.hash.Hex())
+				return true, nil
+			}
+			return false, err
+		}
+	}
+
+	if err := r.claim(ctx, msg); err != nil {
+		var alreadyClaimed *bindings.GasTankAlreadyClaimed
+		if errors.As(err, &alreadyClaimed) {
+			log.Printf("Message %s was already claimed, skipping", msg.hash.Hex())
+			return true, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// authorizedClaimChain returns the chain whose GasTank holds the gas provider's authorization of the message. The
+// chain that emitted the AuthorizedClaims log is checked first, then every other chain in case that log was
+// reorged out.
+func (r *Relayer) authorizedClaimChain(ctx context.Context, msg *pendingMessage) (*gasTankChain, error) {
+	candidates := []uint64{msg.authorizedOn}
+	for _, chainID := range r.chainIDs() {
+		if chainID != msg.authorizedOn {
+			candidates = append(candidates, chainID)
+		}
+	}
+	claimChain, err := authorizedClaimChain(ctx, r.gasTanks, msg.gasProvider, msg.hash, candidates...)
+	if err != nil {
+		return nil, err
+	}
+	if claimChain == nil {
+		return nil, fmt.Errorf("message is not authorized by gas provider %s on chains %v", msg.gasProvider.Hex(), candidates)
+	}
+	return claimChain, nil
+}
+
+// relay relays the message through the GasTank on its destination chain
+func (r *Relayer) relay(ctx context.Context, msg *pendingMessage) error {
+	relayTx, receiptLog, err := relayThroughGasTank(ctx, r.cfg, r.relayerKey, msg.source.gasTankChain, msg.destination.gasTankChain, msg.sentLog)
+	if err != nil {
+		return err
+	}
+	msg.gasReceiptLog = receiptLog
+	log.Printf("Relayed message %s on chain %d: %s", msg.hash.Hex(), msg.destination.ChainID, relayTx.TxHash.Hex())
+	return nil
+}
+
+// claim claims the relay repayment from the gas provider on the chain holding its authorization
+func (r *Relayer) claim(ctx context.Context, msg *pendingMessage) error {
+	claimTx, err := claimFromGasTank(ctx, r.cfg, r.relayerKey, msg.destination.gasTankChain, msg.claimChain, msg.gasProvider, msg.gasReceiptLog)
+	if err != nil {
+		return err
+	}
+	log.Printf("Claimed message %s on chain %d from gas provider %s: %s", msg.hash.Hex(), msg.claimChain.ChainID, msg.gasProvider.Hex(), claimTx.TxHash.Hex())
+	return nil
+}
+
+func (r *Relayer) chainIDs() []uint64 {
+	ids := make([]uint64, 0, len(r.chains))
+	for id := range r.chains {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// sentMessageHash computes the hash of a SentMessage log emitted on the chain with the given chain ID
+func sentMessageHash(chainID *big.Int, sentLog *types.Log) (common.Hash, error) {
+	sentMessage, err := interop.SentMessageFromLog(sentLog)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return sentMessage.Hash(chainID)
+}
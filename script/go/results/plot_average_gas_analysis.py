@@ -5,6 +5,24 @@ import glob
 import numpy as np
 import matplotlib.pyplot as plt
 
+def read_samples(data):
+    """Yields (nested_messages, samples) for every case of a results file, samples holding the relay and claim gas deltas.
+
+    Files list every sample under "cases" with a cost breakdown per transaction (schemaVersion 2, see
+    gas_analysis.schema.json), older files hold a single pair of gas deltas per nested message count.
+    """
+    if 'cases' in data:
+        for case in data['cases']:
+            samples = [{'relay': gas_delta(sample['relay']), 'claim': gas_delta(sample['claim'])} for sample in case['samples']]
+            yield case['nestedMessages'], samples
+        return
+    for key, values in data.items():
+        yield int(key), [values]
+
+def gas_delta(costs):
+    """Returns the gas delta of a transaction, given either as a number or as a cost breakdown."""
+    return costs['gasDelta'] if isinstance(costs, dict) else costs
+
 def generate_gas_analysis_chart():
     # Directory containing the JSON files
     results_dir = os.path.dirname(os.path.realpath(__file__))
@@ -24,13 +42,19 @@ def generate_gas_analysis_chart():
     for file_path in json_files:
         with open(file_path, 'r') as f:
             data = json.load(f)
-            for key, values in data.items():
-                nested_messages = int(key)
+            for nested_messages, samples in read_samples(data):
                 if nested_messages not in aggregated_data:
                     aggregated_data[nested_messages] = {'relay': [], 'claim': []}
 
-                aggregated_data[nested_messages]['relay'].append(values['relay'])
-                aggregated_data[nested_messages]['claim'].append(values['claim'])
+                for values in samples:
+                    aggregated_data[nested_messages]['relay'].append(values['relay'])
+                    aggregated_data[nested_messages]['claim'].append(values['claim'])
+
+    # Skip nested message counts whose runs all failed
+    aggregated_data = {key: values for key, values in aggregated_data.items() if values['relay']}
+    if not aggregated_data:
+        print("No gas analysis samples found.")
+        return
 
     # Sort the data by the number of nested messages
     sorted_keys = sorted(aggregated_data.keys())
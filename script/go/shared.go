@@ -3,33 +3,29 @@ package main
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"errors"
 	"fmt"
+	"log"
 	"math/big"
+	"slices"
 	"strings"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
-)
 
-// Identifier matches the ICrossL2Inbox.Identifier struct
-type Identifier struct {
-	Origin      common.Address `json:"origin" abi:"origin"`
-	BlockNumber *big.Int       `json:"blockNumber" abi:"blockNumber"`
-	LogIndex    *big.Int       `json:"logIndex" abi:"logIndex"`
-	Timestamp   *big.Int       `json:"timestamp" abi:"timestamp"`
-	ChainID     *big.Int       `json:"chainId" abi:"chainId"`
-}
+	"supersim-e2e-example/bindings"
+	"supersim-e2e-example/interop"
+)
 
 // GetAccessListForIdentifierRequest mirrors the structure for the admin RPC call
 type GetAccessListForIdentifierRequest struct {
-	Identifier
+	interop.Identifier
 	Payload string `json:"payload"`
 }
 
@@ -49,121 +45,157 @@ var (
 	l2TokenAddr                = common.HexToAddress("0x420beeF000000000000000000000000000000001")
 	superchainTokenBridgeAddr  = common.HexToAddress("0x4200000000000000000000000000000000000028")
 	l2CrossDomainMessengerAddr = common.HexToAddress("0x4200000000000000000000000000000000000023")
-	crossL2InboxAddr           = common.HexToAddress("0x4200000000000000000000000000000000000022")
+	crossL2InboxAddr           = interop.CrossL2InboxAddress
+	gasPriceOracleAddr         = common.HexToAddress("0x420000000000000000000000000000000000000F")
 
 	// ABIs
-	tokenABI, _                = abi.JSON(strings.NewReader(`[{"inputs":[{"internalType":"address","name":"_to","type":"address"},{"internalType":"uint256","name":"_amount","type":"uint256"}],"name":"mint","outputs":[],"stateMutability":"nonpayable","type":"function"}]`))
-	bridgeABI, _               = abi.JSON(strings.NewReader(`[{"inputs":[{"internalType":"address","name":"_token","type":"address"},{"internalType":"address","name":"_to","type":"address"},{"internalType":"uint256","name":"_amount","type":"uint256"},{"internalType":"uint256","name":"_chainId","type":"uint256"}],"name":"sendERC20","outputs":[],"stateMutability":"nonpayable","type":"function"}]`))
-	crossL2InboxABI, _         = abi.JSON(strings.NewReader(`[{"inputs":[{"components":[{"internalType":"address","name":"origin","type":"address"},{"internalType":"uint256","name":"blockNumber","type":"uint256"},{"internalType":"uint256","name":"logIndex","type":"uint256"},{"internalType":"uint256","name":"timestamp","type":"uint256"},{"internalType":"uint256","name":"chainId","type":"uint256"}],"name":"_id","type":"tuple"},{"internalType":"bytes32","name":"_msgHash","type":"bytes32"}],"name":"calculateChecksum","outputs":[{"internalType":"bytes32","name":"checksum_","type":"bytes32"}],"stateMutability":"pure","type":"function"}]`))
-	crossDomainMessengerABI, _ = abi.JSON(strings.NewReader(`[{"type":"function","name":"crossDomainMessageContext","inputs":[],"outputs":[{"name":"sender_","type":"address","internalType":"address"},{"name":"source_","type":"uint256","internalType":"uint256"}],"stateMutability":"view"},{"type":"function","name":"crossDomainMessageSender","inputs":[],"outputs":[{"name":"sender_","type":"address","internalType":"address"}],"stateMutability":"view"},{"type":"function","name":"crossDomainMessageSource","inputs":[],"outputs":[{"name":"source_","type":"uint256","internalType":"uint256"}],"stateMutability":"view"},{"type":"function","name":"messageNonce","inputs":[],"outputs":[{"name":"","type":"uint256","internalType":"uint256"}],"stateMutability":"view"},{"type":"function","name":"messageVersion","inputs":[],"outputs":[{"name":"","type":"uint16","internalType":"uint16"}],"stateMutability":"view"},{"type":"function","name":"relayMessage","inputs":[{"name":"_id","type":"tuple","internalType":"struct Identifier","components":[{"name":"origin","type":"address","internalType":"address"},{"name":"blockNumber","type":"uint256","internalType":"uint256"},{"name":"logIndex","type":"uint256","internalType":"uint256"},{"name":"timestamp","type":"uint256","internalType":"uint256"},{"name":"chainId","type":"uint256","internalType":"uint256"}]},{"name":"_sentMessage","type":"bytes","internalType":"bytes"}],"outputs":[{"name":"returnData_","type":"bytes","internalType":"bytes"}],"stateMutability":"payable"},{"type":"function","name":"resendMessage","inputs":[{"name":"_destination","type":"uint256","internalType":"uint256"},{"name":"_nonce","type":"uint256","internalType":"uint256"},{"name":"_sender","type":"address","internalType":"address"},{"name":"_target","type":"address","internalType":"address"},{"name":"_message","type":"bytes","internalType":"bytes"}],"outputs":[{"name":"messageHash_","type":"bytes32","internalType":"bytes32"}],"stateMutability":"nonpayable"},{"type":"function","name":"sendMessage","inputs":[{"name":"_destination","type":"uint256","internalType":"uint256"},{"name":"_target","type":"address","internalType":"address"},{"name":"_message","type":"bytes","internalType":"bytes"}],"outputs":[{"name":"messageHash_","type":"bytes32","internalType":"bytes32"}],"stateMutability":"nonpayable"},{"type":"function","name":"sentMessages","inputs":[{"name":"","type":"uint256","internalType":"uint256"}],"outputs":[{"name":"","type":"bytes32","internalType":"bytes32"}],"stateMutability":"view"},{"type":"function","name":"successfulMessages","inputs":[{"name":"","type":"bytes32","internalType":"bytes32"}],"outputs":[{"name":"","type":"bool","internalType":"bool"}],"stateMutability":"view"},{"type":"function","name":"version","inputs":[],"outputs":[{"name":"","type":"string","internalType":"string"}],"stateMutability":"view"},{"type":"event","name":"RelayedMessage","inputs":[{"name":"source","type":"uint256","indexed":true,"internalType":"uint256"},{"name":"messageNonce","type":"uint256","indexed":true,"internalType":"uint256"},{"name":"messageHash","type":"bytes32","indexed":true,"internalType":"bytes32"},{"name":"returnDataHash","type":"bytes32","indexed":false,"internalType":"bytes32"}],"anonymous":false},{"type":"event","name":"SentMessage","inputs":[{"name":"destination","type":"uint256","indexed":true,"internalType":"uint256"},{"name":"target","type":"address","indexed":true,"internalType":"address"},{"name":"messageNonce","type":"uint256","indexed":true,"internalType":"uint256"},{"name":"sender","type":"address","indexed":false,"internalType":"address"},{"name":"message","type":"bytes","indexed":false,"internalType":"bytes"}],"anonymous":false},{"type":"error","name":"EventPayloadNotSentMessage","inputs":[]},{"type":"error","name":"IdOriginNotL2ToL2CrossDomainMessenger","inputs":[]},{"type":"error","name":"InvalidMessage","inputs":[]},{"type":"error","name":"MessageAlreadyRelayed","inputs":[]},{"type":"error","name":"MessageDestinationNotRelayChain","inputs":[]},{"type":"error","name":"MessageDestinationSameChain","inputs":[]},{"type":"error","name":"MessageTargetL2ToL2CrossDomainMessenger","inputs":[]},{"type":"error","name":"NotEntered","inputs":[]},{"type":"error","name":"ReentrantCall","inputs":[]}]`))
-	gasTankABI, _              = abi.JSON(strings.NewReader(`[
-		{"inputs":[{"internalType":"address","name":"_to","type":"address"}],"name":"deposit","outputs":[],"stateMutability":"payable","type":"function"},
-		{"inputs":[{"internalType":"bytes32[]","name":"_messageHashes","type":"bytes32[]"}],"name":"authorizeClaim","outputs":[],"stateMutability":"nonpayable","type":"function"},
-		{"inputs":[{"internalType":"address","name":"gasProvider","type":"address"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"balance","type":"uint256"}],"stateMutability":"view","type":"function"},
-		{"inputs":[{"components":[{"internalType":"address","name":"origin","type":"address"},{"internalType":"uint256","name":"blockNumber","type":"uint256"},{"internalType":"uint256","name":"logIndex","type":"uint256"},{"internalType":"uint256","name":"timestamp","type":"uint256"},{"internalType":"uint256","name":"chainId","type":"uint256"}],"name":"_id","type":"tuple"},{"internalType":"address","name":"_gasProvider","type":"address"},{"internalType":"bytes","name":"_payload","type":"bytes"}],"name":"claim","outputs":[],"stateMutability":"nonpayable","type":"function"},
-		{"inputs":[{"internalType":"uint256","name":"_numHashes","type":"uint256"},{"internalType":"uint256","name":"_baseFee","type":"uint256"},{"internalType":"bytes","name":"_data","type":"bytes"}],"name":"claimOverhead","outputs":[{"internalType":"uint256","name":"l2Cost_","type":"uint256"},{"internalType":"uint256","name":"l1Cost_","type":"uint256"}],"stateMutability":"view","type":"function"},
-		{"type":"function","name":"relayMessage","inputs":[{"name":"_id","type":"tuple","components":[{"name":"origin","type":"address"},{"name":"blockNumber","type":"uint256"},{"name":"logIndex","type":"uint256"},{"name":"timestamp","type":"uint256"},{"name":"chainId","type":"uint256"}]},{"name":"_sentMessage","type":"bytes"}],"outputs":[{"name":"relayCost_","type":"uint256"},{"name":"nestedMessageHashes_","type":"bytes32[]"}],"stateMutability":"nonpayable"},
-		{"type":"event","name":"RelayedMessageGasReceipt","inputs":[{"indexed":true,"name":"messageHash","type":"bytes32"},{"indexed":true,"name":"relayer","type":"address"},{"indexed":false,"name":"relayCost","type":"uint256"},{"indexed":false,"name":"nestedMessageHashes","type":"bytes32[]"}],"anonymous":false}
-	]`))
-	messageSenderABI, _                 = abi.JSON(strings.NewReader(`[{"type":"function","name":"sendMessages","inputs":[{"name":"_destinationChainId","type":"uint256"},{"name":"_numMessages","type":"uint256"}]}]`))
-	sentMessageEventABI, _              = abi.JSON(strings.NewReader(`[{"type":"event","name":"SentMessage","inputs":[{"indexed":true,"name":"destination","type":"uint256"},{"indexed":true,"name":"target","type":"address"},{"indexed":true,"name":"messageNonce","type":"uint256"},{"indexed":false,"name":"sender","type":"address"},{"indexed":false,"name":"message","type":"bytes"}],"anonymous":false}]`))
-	relayedMessageGasReceiptEventABI, _ = abi.JSON(strings.NewReader(`[{"type":"event","name":"RelayedMessageGasReceipt","inputs":[{"indexed":true,"name":"messageHash","type":"bytes32"},{"indexed":true,"name":"relayer","type":"address"},{"indexed":false,"name":"relayCost","type":"uint256"},{"indexed":false,"name":"nestedMessageHashes","type":"bytes32[]"}],"anonymous":false}]`))
-	claimedEventABI, _                  = abi.JSON(strings.NewReader(`[{"type":"event","name":"Claimed","inputs":[{"indexed":true,"name":"originMessageHash","type":"bytes32"},{"indexed":true,"name":"relayer","type":"address"},{"indexed":true,"name":"gasProvider","type":"address"},{"indexed":false,"name":"claimer","type":"address"},{"indexed":false,"name":"relayCost","type":"uint256"},{"indexed":false,"name":"claimCost","type":"uint256"}],"anonymous":false}]`))
-
-	// Events Signatures
-	sentMessageTopic              = crypto.Keccak256Hash([]byte("SentMessage(uint256,address,uint256,address,bytes)"))
-	relayedMessageGasReceiptTopic = crypto.Keccak256Hash([]byte("RelayedMessageGasReceipt(bytes32,address,uint256,bytes32[])"))
-
-	// Event Types
-	bytes32Type, _      = abi.NewType("bytes32", "", nil)
-	bytes32ArrayType, _ = abi.NewType("bytes32[]", "", nil)
-	uint256Type, _      = abi.NewType("uint256", "", nil)
-	addressType, _      = abi.NewType("address", "", nil)
-	bytesType, _        = abi.NewType("bytes", "", nil)
+	tokenABI, _          = abi.JSON(strings.NewReader(`[{"inputs":[{"internalType":"address","name":"_to","type":"address"},{"internalType":"uint256","name":"_amount","type":"uint256"}],"name":"mint","outputs":[],"stateMutability":"nonpayable","type":"function"}]`))
+	gasPriceOracleABI, _ = abi.JSON(strings.NewReader(`[{"inputs":[{"internalType":"bytes","name":"_data","type":"bytes"}],"name":"getL1Fee","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`))
+	bridgeABI, _         = abi.JSON(strings.NewReader(`[{"inputs":[{"internalType":"address","name":"_token","type":"address"},{"internalType":"address","name":"_to","type":"address"},{"internalType":"uint256","name":"_amount","type":"uint256"},{"internalType":"uint256","name":"_chainId","type":"uint256"}],"name":"sendERC20","outputs":[],"stateMutability":"nonpayable","type":"function"}]`))
+
+	// Contract bindings
+	gasTankContract       = bindings.NewGasTank()
+	messageSenderContract = bindings.NewMessageSender()
+	messengerContract     = bindings.NewL2ToL2CrossDomainMessenger()
+	crossL2InboxContract  = bindings.NewCrossL2Inbox()
+
+	// Event topics
+	claimedTopic = eventID(&bindings.GasTankMetaData, bindings.GasTankClaimedEventName)
 )
 
-// sendAndWaitForTransaction is a helper to build, sign, send, and wait for a transaction
-func sendAndWaitForTransaction(client *ethclient.Client, chainID *big.Int, pk *ecdsa.PrivateKey, to *common.Address, value *big.Int, data []byte, accessList ...types.AccessList) (*types.Receipt, error) {
-	fromAddress := crypto.PubkeyToAddress(*pk.Public().(*ecdsa.PublicKey))
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+// findLog returns the first log emitted by address with the given event topic, or nil if there is none
+func findLog(logs []*types.Log, address common.Address, topic common.Hash) *types.Log {
+	for _, logEntry := range logs {
+		if logEntry.Address == address && len(logEntry.Topics) > 0 && logEntry.Topics[0] == topic {
+			return logEntry
+		}
+	}
+	return nil
+}
+
+// eventID returns the topic of an event declared in a binding's ABI
+func eventID(metadata *bind.MetaData, name string) common.Hash {
+	parsed, err := metadata.ParseABI()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
+		panic(fmt.Errorf("invalid %s ABI: %w", metadata.ID, err))
+	}
+	return parsed.Events[name].ID
+}
+
+// bindingIdentifier converts an Identifier to the struct expected by the generated bindings
+func bindingIdentifier(id interop.Identifier) bindings.Identifier {
+	return bindings.Identifier{
+		Origin:      id.Origin,
+		BlockNumber: id.BlockNumber,
+		LogIndex:    id.LogIndex,
+		Timestamp:   id.Timestamp,
+		ChainId:     id.ChainID,
 	}
+}
 
-	latestBlock, err := client.BlockByNumber(context.Background(), nil)
+// callView performs an eth_call to a view function and decodes its result with the binding's unpack method
+func callView[T any](ctx context.Context, client ethereum.ContractCaller, to common.Address, calldata []byte, unpack func([]byte) (T, error)) (T, error) {
+	result, err := client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: calldata}, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest block: %w", err)
+		var zero T
+		return zero, fmt.Errorf("failed to call %s: %w", to.Hex(), err)
 	}
-	gasFeeCap := new(big.Int).Mul(latestBlock.BaseFee(), big.NewInt(2))
+	return unpack(result)
+}
 
-	// For this test, we want to align with the contract's cost calculation, which only uses basefee.
-	// By setting the tip to 0, we ensure the relayer is only paying the base network fee.
-	gasTipCap := big.NewInt(0)
+// weiPerEther is the number of wei in one ether
+var weiPerEther = big.NewInt(1e18)
 
-	txData := &types.DynamicFeeTx{
-		ChainID:   chainID,
-		Nonce:     nonce,
-		GasFeeCap: gasFeeCap,
-		GasTipCap: gasTipCap,
-		To:        to,
-		Value:     value,
-		Data:      data,
-		Gas:       2000000,
+// parseEther parses a decimal ETH amount such as "0.005" into wei
+func parseEther(amount string) (*big.Int, error) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("%q is not a valid ETH amount", amount)
 	}
-	if len(accessList) > 0 {
-		txData.AccessList = accessList[0]
+	value.Mul(value, new(big.Rat).SetInt(weiPerEther))
+	if !value.IsInt() {
+		return nil, fmt.Errorf("%q has more than 18 decimals", amount)
 	}
+	return new(big.Int).Set(value.Num()), nil
+}
 
-	tx := types.NewTx(txData)
-	signedTx, err := types.SignTx(tx, types.NewLondonSigner(chainID), pk)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+// formatEther formats a wei amount in ETH without trailing zeros
+func formatEther(wei *big.Int) string {
+	integer, fraction := new(big.Int).QuoRem(new(big.Int).Abs(wei), weiPerEther, new(big.Int))
+	formatted := integer.String()
+	if fraction.Sign() != 0 {
+		formatted += "." + strings.TrimRight(fmt.Sprintf("%018s", fraction.String()), "0")
 	}
+	if wei.Sign() < 0 {
+		formatted = "-" + formatted
+	}
+	return formatted
+}
 
-	err = client.SendTransaction(context.Background(), signedTx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send transaction: %w", err)
+// revertError turns an RPC error carrying revert data into the typed error decoded by bindings.DecodeRevert,
+// so that callers can match it with errors.As. Other errors are returned unchanged.
+func revertError(err error) error {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return err
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err
 	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil {
+		return err
+	}
+	if decoded := bindings.DecodeRevert(data); decoded != nil {
+		return decoded
+	}
+	return err
+}
 
-	receipt, err := bind.WaitMined(context.Background(), client, signedTx)
+// Sources of the relay and claim access lists
+const (
+	// accessListSourceAdmin asks supersim's admin_getAccessListForIdentifier
+	accessListSourceAdmin = "admin"
+	// accessListSourceLocal computes the access list with interop.NewAccessList, for chains without supersim
+	accessListSourceLocal = "local"
+	// accessListSourceAuto asks the admin RPC and computes the access list locally when the admin RPC fails
+	accessListSourceAuto = "auto"
+	// accessListSourceVerify asks the admin RPC and fails when its answer differs from the local computation
+	accessListSourceVerify = "verify"
+)
+
+// getAccessList returns the access list of a transaction executing the identified message, from the source
+// configured in Config.AccessListSource
+func getAccessList(cfg *Config, id interop.Identifier, payload []byte) (*types.AccessList, error) {
+	if cfg.AccessListSource == accessListSourceLocal {
+		return localAccessList(id, payload)
+	}
+
+	accessList, err := getAdminAccessList(cfg.AdminRPC, id, payload)
 	if err != nil {
-		// If WaitMined returns a receipt, it means the transaction was mined but reverted.
-		// We can use the receipt to get more information.
-		if receipt != nil {
-			// Proceed to the status check below.
-		} else {
-			return nil, fmt.Errorf("failed to wait for transaction to be mined: %w", err)
+		if cfg.AccessListSource != accessListSourceAuto {
+			return nil, err
 		}
+		log.Printf("Computing the access list locally, the admin RPC is unavailable: %v", err)
+		return localAccessList(id, payload)
 	}
 
-	if receipt.Status == 0 {
-		// Transaction failed, try to get the revert reason by re-executing the transaction as a call.
-		fromAddress := crypto.PubkeyToAddress(*pk.Public().(*ecdsa.PublicKey))
-		callMsg := ethereum.CallMsg{
-			From:  fromAddress,
-			To:    to,
-			Value: value,
-			Data:  data,
+	if cfg.AccessListSource == accessListSourceVerify {
+		local, err := localAccessList(id, payload)
+		if err != nil {
+			return nil, err
 		}
-
-		// Re-execute the transaction call at the block it failed in to get the revert reason.
-		_, callErr := client.CallContract(context.Background(), callMsg, receipt.BlockNumber)
-
-		// The error from CallContract should contain the revert reason.
-		if callErr != nil {
-			return nil, fmt.Errorf("transaction failed with status 0. Revert reason: %v", callErr)
+		if !accessListsEqual(*accessList, *local) {
+			return nil, fmt.Errorf("access list from the admin RPC %s differs from the locally computed %s", formatAccessList(*accessList), formatAccessList(*local))
 		}
-
-		return nil, fmt.Errorf("transaction failed with status 0 (revert reason not found)")
 	}
-
-	return receipt, nil
+	return accessList, nil
 }
 
-func getAccessList(id Identifier, payload []byte) (*types.AccessList, error) {
-	// As pointed out, we should use an admin RPC client, similar to relay.go
-	// The relay.go script connects to port 8420 for the supersim admin rpc.
-	rpcClient, err := rpc.Dial("http://localhost:8420")
+func getAdminAccessList(adminRPC string, id interop.Identifier, payload []byte) (*types.AccessList, error) {
+	// Supersim serves admin_getAccessListForIdentifier on its admin RPC, configured through Config.AdminRPC.
+	rpcClient, err := rpc.Dial(adminRPC)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to supersim admin RPC: %w", err)
 	}
@@ -182,3 +214,92 @@ func getAccessList(id Identifier, payload []byte) (*types.AccessList, error) {
 
 	return &result.AccessList, nil
 }
+
+// localAccessList computes the access list the CrossL2Inbox expects from the identifier and payload
+func localAccessList(id interop.Identifier, payload []byte) (*types.AccessList, error) {
+	accessList, err := interop.NewAccessList(id, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute access list: %w", err)
+	}
+	return &accessList, nil
+}
+
+// verifyAccessList checks the relay data before a transaction bound to revert is sent: the log the identifier points
+// to on the source chain must carry the payload, and the access list must contain the checksum
+// CrossL2Inbox.calculateChecksum returns on the executing chain for the message, the storage key validateMessage
+// requires.
+func verifyAccessList(ctx context.Context, source ethereum.LogFilterer, executing ethereum.ContractCaller, id interop.Identifier, payload []byte, accessList types.AccessList) error {
+	identifiedLog, err := logAtIdentifier(ctx, source, id)
+	if err != nil {
+		return err
+	}
+	payloadHash := crypto.Keccak256Hash(payload)
+	if logPayloadHash := crypto.Keccak256Hash(interop.LogPayload(identifiedLog)); logPayloadHash != payloadHash {
+		return fmt.Errorf("the payload does not match the log at block %s index %s: hash %s, the log hashes to %s", id.BlockNumber, id.LogIndex, payloadHash.Hex(), logPayloadHash.Hex())
+	}
+
+	calldata := crossL2InboxContract.PackCalculateChecksum(bindingIdentifier(id), payloadHash)
+	checksum, err := callView(ctx, executing, crossL2InboxAddr, calldata, crossL2InboxContract.UnpackCalculateChecksum)
+	if err != nil {
+		return fmt.Errorf("failed to calculate the message checksum: %w", err)
+	}
+
+	var checksums []common.Hash
+	for _, tuple := range accessList {
+		if tuple.Address != crossL2InboxAddr {
+			continue
+		}
+		for _, key := range tuple.StorageKeys {
+			if key == checksum {
+				return nil
+			}
+			if key[0] == interop.AccessListChecksumPrefix {
+				checksums = append(checksums, key)
+			}
+		}
+	}
+
+	var diff strings.Builder
+	for _, key := range checksums {
+		fmt.Fprintf(&diff, "\n- %s (access list)", key.Hex())
+	}
+	fmt.Fprintf(&diff, "\n+ %s (CrossL2Inbox.calculateChecksum)", common.Hash(checksum).Hex())
+	return fmt.Errorf("access list does not contain the checksum of the message, it was built for another identifier or payload:%s", diff.String())
+}
+
+// logAtIdentifier fetches the log the identifier points to from the source chain
+func logAtIdentifier(ctx context.Context, source ethereum.LogFilterer, id interop.Identifier) (*types.Log, error) {
+	logs, err := source.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: id.BlockNumber,
+		ToBlock:   id.BlockNumber,
+		Addresses: []common.Address{id.Origin},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the logs of block %s: %w", id.BlockNumber, err)
+	}
+	for i := range logs {
+		if id.LogIndex.IsUint64() && uint64(logs[i].Index) == id.LogIndex.Uint64() {
+			return &logs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no log of %s at block %s index %s", id.Origin.Hex(), id.BlockNumber, id.LogIndex)
+}
+
+func accessListsEqual(a, b types.AccessList) bool {
+	return slices.EqualFunc(a, b, func(x, y types.AccessTuple) bool {
+		return x.Address == y.Address && slices.Equal(x.StorageKeys, y.StorageKeys)
+	})
+}
+
+// formatAccessList formats an access list on a single line, for error messages
+func formatAccessList(accessList types.AccessList) string {
+	var tuples []string
+	for _, tuple := range accessList {
+		keys := make([]string, len(tuple.StorageKeys))
+		for i, key := range tuple.StorageKeys {
+			keys[i] = key.Hex()
+		}
+		tuples = append(tuples, fmt.Sprintf("%s: [%s]", tuple.Address.Hex(), strings.Join(keys, ", ")))
+	}
+	return "{" + strings.Join(tuples, "; ") + "}"
+}
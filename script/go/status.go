@@ -0,0 +1,346 @@
+// This script inspects where a message is in its lifecycle: sent on its origin, authorized by a gas provider,
+// relayed on its destination and claimed back from the gas provider's GasTank.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"supersim-e2e-example/bindings"
+	"supersim-e2e-example/interop"
+)
+
+// Lifecycle stages of a message, in order
+const (
+	stageSent       = "sent"
+	stageAuthorized = "authorized"
+	stageRelayed    = "relayed"
+	stageClaimed    = "claimed"
+)
+
+var authorizedClaimsTopic = eventID(&bindings.GasTankMetaData, bindings.GasTankAuthorizedClaimsEventName)
+
+// messageStage is one step of a message's lifecycle. The log fields are empty when the state is known from a
+// contract view but its event was not found in the scanned blocks.
+type messageStage struct {
+	Stage       string          `json:"stage"`
+	Done        bool            `json:"done"`
+	ChainID     uint64          `json:"chainId,omitempty"`
+	BlockNumber uint64          `json:"blockNumber,omitempty"`
+	Timestamp   *time.Time      `json:"timestamp,omitempty"`
+	TxHash      *common.Hash    `json:"txHash,omitempty"`
+	GasProvider *common.Address `json:"gasProvider,omitempty"`
+	Relayer     *common.Address `json:"relayer,omitempty"`
+	RelayCost   *big.Int        `json:"relayCost,omitempty"`
+	ClaimCost   *big.Int        `json:"claimCost,omitempty"`
+}
+
+// messageStatus is the lifecycle timeline of a message
+type messageStatus struct {
+	MessageHash common.Hash `json:"messageHash"`
+	Origin      uint64      `json:"origin,omitempty"`
+	Destination uint64      `json:"destination,omitempty"`
+	// StuckAt is the first stage not reached yet, empty once the message was claimed
+	StuckAt string          `json:"stuckAt,omitempty"`
+	Stages  []*messageStage `json:"stages"`
+}
+
+// messageInspector looks up the state of messages on every configured chain
+type messageInspector struct {
+	chains       map[uint64]*gasTankChain
+	gasProviders []common.Address
+	fromBlock    uint64
+}
+
+// inspectMessage builds the lifecycle timeline of the message with the given hash
+func inspectMessage(ctx context.Context, cfg *Config, hash common.Hash, gasProviders []common.Address, fromBlock uint64) (*messageStatus, error) {
+	contracts, err := loadSupersimContracts(cfg.ContractsFile)
+	if err != nil {
+		return nil, err
+	}
+	chains, err := dialGasTankChains(cfg, contracts)
+	if err != nil {
+		return nil, err
+	}
+	inspector := &messageInspector{chains: chains, gasProviders: gasProviders, fromBlock: fromBlock}
+	return inspector.inspect(ctx, hash)
+}
+
+func (i *messageInspector) inspect(ctx context.Context, hash common.Hash) (*messageStatus, error) {
+	status := &messageStatus{MessageHash: hash}
+	sent, destination, err := i.findSent(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if sent.Done {
+		status.Origin = sent.ChainID
+		status.Destination = destination
+	}
+
+	authorized, err := i.findAuthorized(ctx, hash, sent)
+	if err != nil {
+		return nil, err
+	}
+	relayed, err := i.findRelayed(ctx, hash, destination)
+	if err != nil {
+		return nil, err
+	}
+	claimed, err := i.findClaimed(ctx, hash, authorized)
+	if err != nil {
+		return nil, err
+	}
+
+	status.Stages = []*messageStage{sent, authorized, relayed, claimed}
+	for _, stage := range status.Stages {
+		if !stage.Done {
+			status.StuckAt = stage.Stage
+			break
+		}
+	}
+	return status, nil
+}
+
+// findSent scans every chain for the SentMessage log of the message, which is not indexed by hash, and returns
+// the stage along with the message's destination
+func (i *messageInspector) findSent(ctx context.Context, hash common.Hash) (*messageStage, uint64, error) {
+	stage := &messageStage{Stage: stageSent}
+	for _, chainID := range i.chainIDs() {
+		chain := i.chains[chainID]
+		logs, err := chain.client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(i.fromBlock),
+			Addresses: []common.Address{l2CrossDomainMessengerAddr},
+			Topics:    [][]common.Hash{{interop.SentMessageTopic}},
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to filter SentMessage logs on chain %d: %w", chainID, err)
+		}
+		for _, sentLog := range logs {
+			if sentLog.Removed || len(sentLog.Topics) != 4 {
+				continue
+			}
+			sentHash, err := sentMessageHash(chain.ID(), &sentLog)
+			if err != nil {
+				return nil, 0, err
+			}
+			if sentHash != hash {
+				continue
+			}
+			if err := i.setLog(ctx, stage, chain, &sentLog); err != nil {
+				return nil, 0, err
+			}
+			return stage, new(big.Int).SetBytes(sentLog.Topics[1].Bytes()).Uint64(), nil
+		}
+	}
+	return stage, 0, nil
+}
+
+// findAuthorized checks authorizedMessages for every gas provider, on the origin first. Nested messages are
+// authorized on the chain their parent was claimed on, so every other chain is checked as well.
+func (i *messageInspector) findAuthorized(ctx context.Context, hash common.Hash, sent *messageStage) (*messageStage, error) {
+	stage := &messageStage{Stage: stageAuthorized}
+	candidates := i.chainIDs()
+	if sent.Done {
+		candidates = append([]uint64{sent.ChainID}, slices.DeleteFunc(candidates, func(id uint64) bool { return id == sent.ChainID })...)
+	}
+
+	for _, chainID := range candidates {
+		chain := i.chains[chainID]
+		for _, gasProvider := range i.gasProviders {
+			authorized, err := callView(ctx, chain.client, chain.gasTank, gasTankContract.PackAuthorizedMessages(gasProvider, hash), gasTankContract.UnpackAuthorizedMessages)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check authorization on chain %d: %w", chainID, err)
+			}
+			if !authorized {
+				continue
+			}
+			stage.Done = true
+			stage.ChainID = chainID
+			stage.GasProvider = &gasProvider
+
+			// The AuthorizedClaims event lists the hashes in its data, only the gas provider is indexed
+			logs, err := chain.client.FilterLogs(ctx, ethereum.FilterQuery{
+				FromBlock: new(big.Int).SetUint64(i.fromBlock),
+				Addresses: []common.Address{chain.gasTank},
+				Topics:    [][]common.Hash{{authorizedClaimsTopic}, {common.BytesToHash(gasProvider.Bytes())}},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to filter AuthorizedClaims logs on chain %d: %w", chainID, err)
+			}
+			for _, authLog := range logs {
+				event, err := gasTankContract.UnpackAuthorizedClaimsEvent(&authLog)
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode AuthorizedClaims event: %w", err)
+				}
+				if slices.Contains(event.MessageHashes, [32]byte(hash)) {
+					return stage, i.setLog(ctx, stage, chain, &authLog)
+				}
+			}
+			return stage, nil
+		}
+	}
+	return stage, nil
+}
+
+// findRelayed checks successfulMessages on the destination and finds the RelayedMessageGasReceipt of the relay
+func (i *messageInspector) findRelayed(ctx context.Context, hash common.Hash, destination uint64) (*messageStage, error) {
+	stage := &messageStage{Stage: stageRelayed}
+	chain, ok := i.chains[destination]
+	if !ok {
+		return stage, nil
+	}
+	stage.ChainID = destination
+
+	relayed, err := callView(ctx, chain.client, l2CrossDomainMessengerAddr, messengerContract.PackSuccessfulMessages(hash), messengerContract.UnpackSuccessfulMessages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check successfulMessages on chain %d: %w", destination, err)
+	}
+	stage.Done = relayed
+	if !relayed {
+		return stage, nil
+	}
+
+	receiptLog, err := i.findIndexedLog(ctx, chain, interop.RelayedMessageGasReceiptTopic, hash)
+	if err != nil || receiptLog == nil {
+		// Relayed without the GasTank, or in blocks before fromBlock
+		return stage, err
+	}
+	receipt, err := gasTankContract.UnpackRelayedMessageGasReceiptEvent(receiptLog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode RelayedMessageGasReceipt event: %w", err)
+	}
+	stage.Relayer = &receipt.Relayer
+	stage.RelayCost = receipt.RelayCost
+	return stage, i.setLog(ctx, stage, chain, receiptLog)
+}
+
+// findClaimed checks claimed on the chain the message was authorized on and finds its Claimed log
+func (i *messageInspector) findClaimed(ctx context.Context, hash common.Hash, authorized *messageStage) (*messageStage, error) {
+	stage := &messageStage{Stage: stageClaimed}
+	chain, ok := i.chains[authorized.ChainID]
+	if !authorized.Done || !ok {
+		return stage, nil
+	}
+	stage.ChainID = authorized.ChainID
+
+	claimed, err := callView(ctx, chain.client, chain.gasTank, gasTankContract.PackClaimed(hash), gasTankContract.UnpackClaimed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check claimed on chain %d: %w", chain.ChainID, err)
+	}
+	stage.Done = claimed
+	if !claimed {
+		return stage, nil
+	}
+
+	claimedLog, err := i.findIndexedLog(ctx, chain, claimedTopic, hash)
+	if err != nil || claimedLog == nil {
+		return stage, err
+	}
+	event, err := gasTankContract.UnpackClaimedEvent(claimedLog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Claimed event: %w", err)
+	}
+	stage.GasProvider = &event.GasProvider
+	stage.Relayer = &event.Relayer
+	stage.RelayCost = event.RelayCost
+	stage.ClaimCost = event.ClaimCost
+	return stage, i.setLog(ctx, stage, chain, claimedLog)
+}
+
+// findIndexedLog returns the first GasTank log with the given event whose first indexed topic is the message hash
+func (i *messageInspector) findIndexedLog(ctx context.Context, chain *gasTankChain, topic, hash common.Hash) (*types.Log, error) {
+	logs, err := chain.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(i.fromBlock),
+		Addresses: []common.Address{chain.gasTank},
+		Topics:    [][]common.Hash{{topic}, {hash}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter logs on chain %d: %w", chain.ChainID, err)
+	}
+	for _, l := range logs {
+		if !l.Removed {
+			return &l, nil
+		}
+	}
+	return nil, nil
+}
+
+// setLog marks the stage as done by the transaction that emitted the log
+func (i *messageInspector) setLog(ctx context.Context, stage *messageStage, chain *gasTankChain, l *types.Log) error {
+	header, err := chain.client.HeaderByNumber(ctx, new(big.Int).SetUint64(l.BlockNumber))
+	if err != nil {
+		return fmt.Errorf("failed to get block %d on chain %d: %w", l.BlockNumber, chain.ChainID, err)
+	}
+	timestamp := time.Unix(int64(header.Time), 0).UTC()
+	stage.Done = true
+	stage.ChainID = chain.ChainID
+	stage.BlockNumber = l.BlockNumber
+	stage.Timestamp = &timestamp
+	stage.TxHash = &l.TxHash
+	return nil
+}
+
+func (i *messageInspector) chainIDs() []uint64 {
+	ids := make([]uint64, 0, len(i.chains))
+	for id := range i.chains {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// writeJSON prints the status as indented JSON
+func (s *messageStatus) writeJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// writeText prints the status as a timeline, one line per stage
+func (s *messageStatus) writeText(w io.Writer) {
+	fmt.Fprintf(w, "Message %s", s.MessageHash.Hex())
+	if s.Origin != 0 {
+		fmt.Fprintf(w, " from chain %d to chain %d", s.Origin, s.Destination)
+	}
+	fmt.Fprintln(w)
+
+	for _, stage := range s.Stages {
+		if !stage.Done {
+			fmt.Fprintf(w, "  %-11s pending\n", stage.Stage)
+			continue
+		}
+		fmt.Fprintf(w, "  %-11s chain %d", stage.Stage, stage.ChainID)
+		if stage.TxHash != nil {
+			fmt.Fprintf(w, ", block %d at %s, tx %s", stage.BlockNumber, stage.Timestamp.Format(time.RFC3339), stage.TxHash.Hex())
+		} else {
+			fmt.Fprint(w, " (event not found in the scanned blocks)")
+		}
+		if stage.GasProvider != nil {
+			fmt.Fprintf(w, ", gas provider %s", stage.GasProvider.Hex())
+		}
+		if stage.Relayer != nil {
+			fmt.Fprintf(w, ", relayer %s", stage.Relayer.Hex())
+		}
+		if stage.RelayCost != nil {
+			fmt.Fprintf(w, ", relay cost %s ETH", formatEther(stage.RelayCost))
+		}
+		if stage.ClaimCost != nil {
+			fmt.Fprintf(w, ", claim cost %s ETH", formatEther(stage.ClaimCost))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if s.StuckAt == "" {
+		fmt.Fprintln(w, "Message fully relayed and claimed")
+	} else {
+		fmt.Fprintf(w, "Waiting to be %s\n", s.StuckAt)
+	}
+}
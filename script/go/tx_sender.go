@@ -0,0 +1,462 @@
+// This file contains the transaction sender shared by every script: it prices, estimates, signs and sends
+// transactions and waits for them to be mined.
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Fee strategy names accepted in TxConfig.FeeStrategy
+const (
+	feeStrategyZeroTip  = "zero-tip"
+	feeStrategyPriority = "priority"
+	feeStrategyLegacy   = "legacy"
+)
+
+// txBackend is the subset of ethclient.Client a TxSender needs
+type txBackend interface {
+	bind.DeployBackend
+	ethereum.ContractCaller
+	ethereum.GasEstimator
+	ethereum.GasPricer
+	ethereum.GasPricer1559
+	ethereum.TransactionSender
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+}
+
+// txFees is the pricing of a transaction. GasPrice is only set for legacy transactions.
+type txFees struct {
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+	GasPrice  *big.Int
+}
+
+// FeeStrategy prices a transaction given the latest block header
+type FeeStrategy interface {
+	Fees(ctx context.Context, backend txBackend, head *types.Header) (txFees, error)
+}
+
+// zeroTipFees pays the base fee only, which keeps the relayer's actual cost equal to the cost the GasTank
+// computes from block.basefee
+type zeroTipFees struct {
+	feeCapMultiplier int64
+}
+
+func (f zeroTipFees) Fees(_ context.Context, _ txBackend, head *types.Header) (txFees, error) {
+	return txFees{
+		GasFeeCap: new(big.Int).Mul(head.BaseFee, big.NewInt(f.feeCapMultiplier)),
+		GasTipCap: new(big.Int),
+	}, nil
+}
+
+// priorityFees adds a priority tip on top of the base fee, the node's suggestion when tip is nil
+type priorityFees struct {
+	feeCapMultiplier int64
+	tip              *big.Int
+}
+
+func (f priorityFees) Fees(ctx context.Context, backend txBackend, head *types.Header) (txFees, error) {
+	tip := f.tip
+	if tip == nil {
+		suggested, err := backend.SuggestGasTipCap(ctx)
+		if err != nil {
+			return txFees{}, fmt.Errorf("failed to suggest gas tip: %w", err)
+		}
+		tip = suggested
+	}
+	feeCap := new(big.Int).Mul(head.BaseFee, big.NewInt(f.feeCapMultiplier))
+	return txFees{GasFeeCap: feeCap.Add(feeCap, tip), GasTipCap: new(big.Int).Set(tip)}, nil
+}
+
+// legacyFees sends pre-EIP-1559 transactions at the node's suggested gas price
+type legacyFees struct{}
+
+func (legacyFees) Fees(ctx context.Context, backend txBackend, _ *types.Header) (txFees, error) {
+	gasPrice, err := backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return txFees{}, fmt.Errorf("failed to suggest gas price: %w", err)
+	}
+	return txFees{GasPrice: gasPrice}, nil
+}
+
+// newFeeStrategy returns the fee strategy selected in the configuration
+func newFeeStrategy(cfg TxConfig) (FeeStrategy, error) {
+	switch cfg.FeeStrategy {
+	case feeStrategyZeroTip:
+		return zeroTipFees{feeCapMultiplier: int64(cfg.FeeCapMultiplier)}, nil
+	case feeStrategyPriority:
+		strategy := priorityFees{feeCapMultiplier: int64(cfg.FeeCapMultiplier)}
+		if cfg.TipWei != 0 {
+			strategy.tip = new(big.Int).SetUint64(cfg.TipWei)
+		}
+		return strategy, nil
+	case feeStrategyLegacy:
+		return legacyFees{}, nil
+	}
+	return nil, fmt.Errorf("unknown fee strategy %q, expected %s, %s or %s", cfg.FeeStrategy, feeStrategyZeroTip, feeStrategyPriority, feeStrategyLegacy)
+}
+
+// minFeeBump is the smallest fee increase, in percent, nodes accept for a replacement transaction
+const minFeeBump = 10
+
+// receiptPollInterval is the interval between two receipt lookups while waiting for a transaction
+const receiptPollInterval = time.Second
+
+// maxNonceRetries bounds how many times a transaction is re-signed with a fresh nonce after the node reported
+// its nonce as already used
+const maxNonceRetries = 3
+
+// TxSender sends transactions from a single account on a single chain. Nonces are handed out locally by its
+// nonceManager, so a TxSender can be used concurrently and many transactions can be in flight at once. Share one
+// TxSender per account and connection, as gasTankChain.sender does, rather than creating several.
+type TxSender struct {
+	backend txBackend
+	chainID *big.Int
+	key     *ecdsa.PrivateKey
+	from    common.Address
+	fees    FeeStrategy
+	nonces  *nonceManager
+	// gasMargin is the percentage added on top of the estimated gas
+	gasMargin uint64
+	// gasLimit skips the estimation when non-zero
+	gasLimit uint64
+	// timeout bounds the time waited for a transaction to be mined
+	timeout time.Duration
+	// resubmitAfter is the time after which a pending transaction is replaced with bumped fees, 0 to never replace
+	resubmitAfter time.Duration
+	// feeBump and maxFeeBump are the percentages fees are raised by per replacement and in total
+	feeBump    uint64
+	maxFeeBump uint64
+}
+
+// NewTxSender creates a sender for the account of key on the chain with the given chain ID
+func NewTxSender(backend txBackend, chainID *big.Int, key *ecdsa.PrivateKey, cfg TxConfig) (*TxSender, error) {
+	fees, err := newFeeStrategy(cfg)
+	if err != nil {
+		return nil, err
+	}
+	from := crypto.PubkeyToAddress(*key.Public().(*ecdsa.PublicKey))
+	return &TxSender{
+		backend:   backend,
+		chainID:   new(big.Int).Set(chainID),
+		key:       key,
+		from:      from,
+		fees:      fees,
+		nonces:    newNonceManager(backend, from),
+		gasMargin: cfg.GasMargin,
+		gasLimit:  cfg.GasLimit,
+		timeout:   cfg.Timeout,
+
+		resubmitAfter: cfg.ResubmitAfter,
+		feeBump:       cfg.FeeBump,
+		maxFeeBump:    cfg.MaxFeeBump,
+	}, nil
+}
+
+// From returns the address transactions are sent from
+func (s *TxSender) From() common.Address {
+	return s.from
+}
+
+// txRequest is a priced and estimated transaction waiting for its nonce
+type txRequest struct {
+	to         *common.Address
+	value      *big.Int
+	data       []byte
+	accessList types.AccessList
+	fees       txFees
+	gas        uint64
+}
+
+// PendingTx is a transaction that was sent and not waited for yet
+type PendingTx struct {
+	sender  *TxSender
+	request txRequest
+	// initialFees are the fees of the first transaction sent, which bound the replacements' fees
+	initialFees txFees
+	// tx is the latest transaction sent, txs every transaction sent with this nonce as any of them may be mined
+	tx  *types.Transaction
+	txs []*types.Transaction
+}
+
+// Hash returns the hash of the latest transaction sent, the mined one once Wait returned a receipt
+func (p *PendingTx) Hash() common.Hash {
+	return p.tx.Hash()
+}
+
+// Send builds, signs and sends a transaction, then waits for it to be mined. A reverted transaction is
+// reported with the decoded revert error, see revertError.
+func (s *TxSender) Send(ctx context.Context, to *common.Address, value *big.Int, data []byte, accessList ...types.AccessList) (*types.Receipt, error) {
+	pending, err := s.SendAsync(ctx, to, value, data, accessList...)
+	if err != nil {
+		return nil, err
+	}
+	return pending.Wait(ctx)
+}
+
+// SendAsync builds, signs and sends a transaction without waiting for it to be mined
+func (s *TxSender) SendAsync(ctx context.Context, to *common.Address, value *big.Int, data []byte, accessList ...types.AccessList) (*PendingTx, error) {
+	request := txRequest{to: to, value: value, data: data}
+	if len(accessList) > 0 {
+		request.accessList = accessList[0]
+	}
+
+	head, err := s.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	if request.fees, err = s.fees.Fees(ctx, s.backend, head); err != nil {
+		return nil, err
+	}
+	if request.gas, err = s.estimateGas(ctx, request); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		nonce, err := s.nonces.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		signedTx, err := s.signAndSend(ctx, request, nonce)
+		if err == nil {
+			return &PendingTx{sender: s, request: request, initialFees: request.fees, tx: signedTx, txs: []*types.Transaction{signedTx}}, nil
+		}
+		if !isNonceTooLow(err) {
+			// The transaction was not accepted so its nonce is still unused, hand it out again
+			s.nonces.Release(nonce)
+			return nil, fmt.Errorf("failed to send transaction: %w", err)
+		}
+		if attempt == maxNonceRetries {
+			return nil, fmt.Errorf("failed to send transaction: %w", err)
+		}
+		// Another process sent from this account, pick up from the node's pending nonce
+		if err := s.nonces.Resync(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// signAndSend signs the request with the given nonce and sends it. A node that already has this exact
+// transaction in its pool, for example after a send whose response was lost, counts as a successful send.
+func (s *TxSender) signAndSend(ctx context.Context, request txRequest, nonce uint64) (*types.Transaction, error) {
+	signedTx, err := types.SignTx(s.newTransaction(request, nonce), types.LatestSignerForChainID(s.chainID), s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	if err := s.backend.SendTransaction(ctx, signedTx); err != nil && !isAlreadyKnown(err) {
+		return nil, err
+	}
+	return signedTx, nil
+}
+
+// Wait waits for the transaction to be mined, replacing it with bumped fees whenever it stays pending for
+// longer than the configured resubmit delay. A transaction the node no longer knows about once the timeout
+// expired was dropped: its nonce is released so that the next transaction fills the gap.
+func (p *PendingTx) Wait(ctx context.Context) (*types.Receipt, error) {
+	s := p.sender
+	receipt, err := p.waitMined(ctx)
+	if err != nil {
+		if ctx.Err() == nil && p.dropped() {
+			s.nonces.Release(p.tx.Nonce())
+			return nil, fmt.Errorf("transaction %s was dropped: %w", p.Hash().Hex(), err)
+		}
+		return nil, err
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		// Re-execute the transaction as a call at the block it failed in to get the revert reason
+		request := p.request
+		callMsg := ethereum.CallMsg{From: s.from, To: request.to, Value: request.value, Data: request.data, AccessList: request.accessList}
+		if _, callErr := s.backend.CallContract(ctx, callMsg, receipt.BlockNumber); callErr != nil {
+			return receipt, fmt.Errorf("transaction %s failed with status 0: %w", p.Hash().Hex(), revertError(callErr))
+		}
+		return receipt, fmt.Errorf("transaction %s failed with status 0 (revert reason not found)", p.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+// dropped reports whether the node forgot about every transaction sent with the nonce
+func (p *PendingTx) dropped() bool {
+	for _, tx := range p.txs {
+		if _, _, err := p.sender.backend.TransactionByHash(context.Background(), tx.Hash()); !errors.Is(err, ethereum.NotFound) {
+			return false
+		}
+	}
+	return true
+}
+
+// newTransaction builds the unsigned transaction of the request with the given nonce
+func (s *TxSender) newTransaction(request txRequest, nonce uint64) *types.Transaction {
+	fees := request.fees
+	if fees.GasPrice != nil {
+		if len(request.accessList) == 0 {
+			return types.NewTx(&types.LegacyTx{Nonce: nonce, GasPrice: fees.GasPrice, Gas: request.gas, To: request.to, Value: request.value, Data: request.data})
+		}
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    s.chainID,
+			Nonce:      nonce,
+			GasPrice:   fees.GasPrice,
+			Gas:        request.gas,
+			To:         request.to,
+			Value:      request.value,
+			Data:       request.data,
+			AccessList: request.accessList,
+		})
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:    s.chainID,
+		Nonce:      nonce,
+		GasFeeCap:  fees.GasFeeCap,
+		GasTipCap:  fees.GasTipCap,
+		Gas:        request.gas,
+		To:         request.to,
+		Value:      request.value,
+		Data:       request.data,
+		AccessList: request.accessList,
+	})
+}
+
+// estimateGas estimates the gas of the transaction, access list included, and adds the configured margin.
+// A transaction that would revert fails here with the decoded revert error instead of being sent.
+func (s *TxSender) estimateGas(ctx context.Context, request txRequest) (uint64, error) {
+	if s.gasLimit != 0 {
+		return s.gasLimit, nil
+	}
+	estimated, err := s.backend.EstimateGas(ctx, ethereum.CallMsg{
+		From:       s.from,
+		To:         request.to,
+		Value:      request.value,
+		Data:       request.data,
+		AccessList: request.accessList,
+		GasPrice:   request.fees.GasPrice,
+		GasFeeCap:  request.fees.GasFeeCap,
+		GasTipCap:  request.fees.GasTipCap,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate gas: %w", revertError(err))
+	}
+	return estimated + estimated*s.gasMargin/100, nil
+}
+
+// waitMined waits for any of the transactions sent with the nonce to be mined, giving up after the configured timeout
+func (p *PendingTx) waitMined(ctx context.Context) (*types.Receipt, error) {
+	s := p.sender
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	lastSent := time.Now()
+	for {
+		// The latest replacement is the most likely to be mined, look it up first
+		for i := len(p.txs) - 1; i >= 0; i-- {
+			receipt, err := s.backend.TransactionReceipt(ctx, p.txs[i].Hash())
+			if err == nil {
+				p.tx = p.txs[i]
+				return receipt, nil
+			}
+		}
+		if s.resubmitAfter > 0 && time.Since(lastSent) >= s.resubmitAfter {
+			p.replace(ctx)
+			lastSent = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("transaction %s was not mined within %s", p.Hash().Hex(), s.timeout)
+			}
+			return nil, fmt.Errorf("failed to wait for transaction %s to be mined: %w", p.Hash().Hex(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// replace resends the transaction with the same nonce and bumped fees, unless the bump would exceed the
+// configured maximum. Failures are logged only, the transactions already sent may still be mined.
+func (p *PendingTx) replace(ctx context.Context) {
+	s := p.sender
+	fees, ok := bumpFees(p.request.fees, p.initialFees, s.feeBump, s.maxFeeBump)
+	if !ok {
+		log.Printf("Transaction %s still pending after %s, not replacing it as the fees reached the maximum bump of %d%%", p.Hash().Hex(), s.resubmitAfter, s.maxFeeBump)
+		return
+	}
+
+	request := p.request
+	request.fees = fees
+	replacement, err := s.signAndSend(ctx, request, p.tx.Nonce())
+	if err != nil {
+		log.Printf("Failed to replace transaction %s still pending after %s: %v", p.Hash().Hex(), s.resubmitAfter, err)
+		return
+	}
+	log.Printf("Replaced transaction %s still pending after %s with %s (nonce %d, %s)", p.Hash().Hex(), s.resubmitAfter, replacement.Hash().Hex(), replacement.Nonce(), p.request.fees.bumpedTo(fees))
+	p.request = request
+	p.tx = replacement
+	p.txs = append(p.txs, replacement)
+}
+
+// bumpFees raises every fee by bump percent, rounding up and by at least 1 wei: nodes only accept a replacement
+// whose fee cap and tip are both strictly higher, which a percentage of a zero tip never is. It reports false when
+// the fee cap or gas price would exceed its initial value raised by maxBump percent. The tip is not limited, it
+// starts at zero with the zero-tip strategy and never makes the transaction pay more than its fee cap.
+func bumpFees(fees, initial txFees, bump, maxBump uint64) (txFees, bool) {
+	var bumped txFees
+	for _, f := range []struct {
+		dst              **big.Int
+		current, initial *big.Int
+		limited          bool
+	}{
+		{&bumped.GasFeeCap, fees.GasFeeCap, initial.GasFeeCap, true},
+		{&bumped.GasTipCap, fees.GasTipCap, initial.GasTipCap, false},
+		{&bumped.GasPrice, fees.GasPrice, initial.GasPrice, true},
+	} {
+		if f.current == nil {
+			continue
+		}
+		*f.dst = bumpFee(f.current, bump)
+		if f.limited && (*f.dst).Cmp(maxBumpedFee(f.initial, maxBump)) > 0 {
+			return txFees{}, false
+		}
+	}
+	return bumped, true
+}
+
+// bumpFee returns max(ceil(current * (100 + bump) / 100), current + 1)
+func bumpFee(current *big.Int, bump uint64) *big.Int {
+	bumped := new(big.Int).Mul(current, new(big.Int).SetUint64(100+bump))
+	bumped.Add(bumped, big.NewInt(99)).Div(bumped, big.NewInt(100))
+	if minimum := new(big.Int).Add(current, big.NewInt(1)); bumped.Cmp(minimum) < 0 {
+		return minimum
+	}
+	return bumped
+}
+
+// maxBumpedFee is the highest value a fee starting at initial may be bumped to
+func maxBumpedFee(initial *big.Int, maxBump uint64) *big.Int {
+	limit := new(big.Int).Mul(initial, new(big.Int).SetUint64(100+maxBump))
+	return limit.Div(limit, big.NewInt(100))
+}
+
+// bumpedTo describes the change from these fees to the bumped ones for the logs
+func (f txFees) bumpedTo(bumped txFees) string {
+	if f.GasPrice != nil {
+		return fmt.Sprintf("gas price %s -> %s wei", f.GasPrice, bumped.GasPrice)
+	}
+	return fmt.Sprintf("fee cap %s -> %s wei, tip %s -> %s wei", f.GasFeeCap, bumped.GasFeeCap, f.GasTipCap, bumped.GasTipCap)
+}
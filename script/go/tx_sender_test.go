@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+func TestBumpFees(t *testing.T) {
+	tests := []struct {
+		name          string
+		fees, initial txFees
+		bump, maxBump uint64
+		want          txFees
+		wantOK        bool
+	}{
+		{
+			name:    "zero tip",
+			fees:    eip1559Fees(2000, 0),
+			initial: eip1559Fees(2000, 0),
+			bump:    10,
+			maxBump: 100,
+			want:    eip1559Fees(2200, 1),
+			wantOK:  true,
+		},
+		{
+			name:    "1 wei tip",
+			fees:    eip1559Fees(2, 1),
+			initial: eip1559Fees(2, 1),
+			bump:    10,
+			maxBump: 100,
+			want:    eip1559Fees(3, 2),
+			wantOK:  true,
+		},
+		{
+			name:    "legacy gas price",
+			fees:    txFees{GasPrice: big.NewInt(1000)},
+			initial: txFees{GasPrice: big.NewInt(1000)},
+			bump:    10,
+			maxBump: 100,
+			want:    txFees{GasPrice: big.NewInt(1100)},
+			wantOK:  true,
+		},
+		{
+			name:    "fee cap reaching the maximum bump",
+			fees:    eip1559Fees(1818, 7),
+			initial: eip1559Fees(1000, 0),
+			bump:    10,
+			maxBump: 100,
+			want:    eip1559Fees(2000, 8),
+			wantOK:  true,
+		},
+		{
+			name:    "fee cap above the maximum bump",
+			fees:    eip1559Fees(1819, 7),
+			initial: eip1559Fees(1000, 0),
+			bump:    10,
+			maxBump: 100,
+		},
+		{
+			name:    "gas price above the maximum bump",
+			fees:    txFees{GasPrice: big.NewInt(1500)},
+			initial: txFees{GasPrice: big.NewInt(1000)},
+			bump:    50,
+			maxBump: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := bumpFees(tt.fees, tt.initial, tt.bump, tt.maxBump)
+			if ok != tt.wantOK {
+				t.Fatalf("bumpFees ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if !equalFees(got, tt.want) {
+				t.Errorf("bumpFees = %s, want %s", tt.fees.bumpedTo(got), tt.fees.bumpedTo(tt.want))
+			}
+			if !acceptedAsReplacement(tt.fees, got, minFeeBump) {
+				t.Errorf("the txpool rejects %s as a replacement", tt.fees.bumpedTo(got))
+			}
+		})
+	}
+}
+
+// TestBumpFeesReplacesZeroTip bumps zero-tip fees until the maximum bump, every replacement must be accepted
+func TestBumpFeesReplacesZeroTip(t *testing.T) {
+	initial := eip1559Fees(100, 0)
+	fees := initial
+	replacements := 0
+	for {
+		bumped, ok := bumpFees(fees, initial, minFeeBump, 100)
+		if !ok {
+			break
+		}
+		if !acceptedAsReplacement(fees, bumped, minFeeBump) {
+			t.Fatalf("replacement %d: the txpool rejects %s", replacements+1, fees.bumpedTo(bumped))
+		}
+		fees = bumped
+		replacements++
+	}
+	// 100 -> 110 -> 121 -> 134 -> 148 -> 163 -> 180 -> 198, the next bump would be 218
+	if replacements != 7 {
+		t.Errorf("replacements = %d, want 7", replacements)
+	}
+}
+
+// TestTxSenderReplacesPendingTransaction sends a 1 wei tip transaction to a simulated chain that mines from 2 wei
+// tips only, it is mined once the resubmit delay has passed and a replacement bumped the tip
+func TestTxSenderReplacesPendingTransaction(t *testing.T) {
+	cfg := defaultConfig()
+	key, err := parsePrivateKey(cfg.GasProviderKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := crypto.PubkeyToAddress(*key.Public().(*ecdsa.PublicKey))
+	_, chainCfg := startSimulatedChain(t, cfg.Origin.ChainID, types.GenesisAlloc{from: {Balance: weiPerEther}}, simulated.WithMinerMinTip(big.NewInt(2)))
+	client, err := chainCfg.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Tx.FeeStrategy = feeStrategyPriority
+	cfg.Tx.TipWei = 1
+	cfg.Tx.Timeout = 30 * time.Second
+	cfg.Tx.ResubmitAfter = 500 * time.Millisecond
+	sender, err := NewTxSender(client, chainCfg.ID(), key, cfg.Tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	pending, err := sender.SendAsync(ctx, &to, big.NewInt(1), nil)
+	if err != nil {
+		t.Fatalf("SendAsync: %v", err)
+	}
+	original := pending.Hash()
+	receipt, err := pending.Wait(ctx)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if receipt.TxHash == original {
+		t.Fatalf("the original transaction %s was mined, want a replacement", original.Hex())
+	}
+	mined, _, err := client.TransactionByHash(ctx, receipt.TxHash)
+	if err != nil {
+		t.Fatalf("failed to get the mined transaction: %v", err)
+	}
+	if mined.GasTipCap().Cmp(big.NewInt(2)) < 0 || mined.Nonce() != 0 {
+		t.Errorf("mined transaction has tip %s and nonce %d, want a replacement of nonce 0 with a tip of at least 2 wei", mined.GasTipCap(), mined.Nonce())
+	}
+}
+
+// acceptedAsReplacement mirrors the replacement rule of geth's legacypool (list.Add): the fee cap and tip must both
+// be strictly higher and at least priceBump percent above the old ones. Legacy gas prices count as both.
+func acceptedAsReplacement(old, replacement txFees, priceBump uint64) bool {
+	oldFeeCap, oldTip := old.GasFeeCap, old.GasTipCap
+	newFeeCap, newTip := replacement.GasFeeCap, replacement.GasTipCap
+	if old.GasPrice != nil {
+		oldFeeCap, oldTip = old.GasPrice, old.GasPrice
+		newFeeCap, newTip = replacement.GasPrice, replacement.GasPrice
+	}
+	if oldFeeCap.Cmp(newFeeCap) >= 0 || oldTip.Cmp(newTip) >= 0 {
+		return false
+	}
+	threshold := func(old *big.Int) *big.Int {
+		scaled := new(big.Int).Mul(old, new(big.Int).SetUint64(100+priceBump))
+		return scaled.Div(scaled, big.NewInt(100))
+	}
+	return newFeeCap.Cmp(threshold(oldFeeCap)) >= 0 && newTip.Cmp(threshold(oldTip)) >= 0
+}
+
+func eip1559Fees(feeCap, tip int64) txFees {
+	return txFees{GasFeeCap: big.NewInt(feeCap), GasTipCap: big.NewInt(tip)}
+}
+
+func equalFees(a, b txFees) bool {
+	equal := func(x, y *big.Int) bool {
+		return (x == nil && y == nil) || (x != nil && y != nil && x.Cmp(y) == 0)
+	}
+	return equal(a.GasFeeCap, b.GasFeeCap) && equal(a.GasTipCap, b.GasTipCap) && equal(a.GasPrice, b.GasPrice)
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.25;
+
+import {Identifier} from "optimism/interfaces/L2/ICrossL2Inbox.sol";
+
+/// @title CrossL2InboxStub
+/// @notice Stand-in for the CrossL2Inbox predeploy used by the Go test harness on simulated chains. Every message is
+///         valid, as simulated chains have no interop dependency set, but checksums are computed like the real inbox
+///         so that the access lists the scripts send can be checked.
+contract CrossL2InboxStub {
+    event ExecutingMessage(bytes32 indexed msgHash, Identifier id);
+
+    /// @notice Accepts any message
+    /// @param _id The identifier of the message
+    /// @param _msgHash The hash of the message payload
+    function validateMessage(Identifier calldata _id, bytes32 _msgHash) external {
+        emit ExecutingMessage(_msgHash, _id);
+    }
+
+    /// @notice Calculates the checksum of a message, the storage key its access list must contain
+    /// @param _id The identifier of the message
+    /// @param _msgHash The hash of the message payload
+    /// @return checksum_ The checksum, prefixed with 0x03
+    function calculateChecksum(Identifier memory _id, bytes32 _msgHash) public pure returns (bytes32 checksum_) {
+        bytes32 logHash = keccak256(abi.encodePacked(_id.origin, _msgHash));
+
+        // 12 bytes of padding, then the block number, timestamp and log index
+        uint256 idPacked = (_id.blockNumber << 96) | (_id.timestamp << 32) | _id.logIndex;
+        bytes32 idLogHash = keccak256(abi.encodePacked(logHash, idPacked));
+
+        bytes32 bareChecksum = keccak256(abi.encodePacked(idLogHash, _id.chainId));
+        checksum_ = (bareChecksum & ~bytes32(uint256(0xff) << 248)) | bytes32(uint256(0x03) << 248);
+    }
+}
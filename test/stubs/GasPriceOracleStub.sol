@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.25;
+
+/// @title GasPriceOracleStub
+/// @notice Stand-in for the GasPriceOracle predeploy used by the Go test harness on simulated chains, which have no
+///         L1 data fee
+contract GasPriceOracleStub {
+    /// @notice Returns the L1 fee of a transaction, always zero
+    /// @return The L1 fee
+    function getL1Fee(bytes calldata) external pure returns (uint256) {
+        return 0;
+    }
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.25;
+
+import {ICrossL2Inbox, Identifier} from "optimism/interfaces/L2/ICrossL2Inbox.sol";
+import {Hashing} from "optimism/src/libraries/Hashing.sol";
+import {Predeploys} from "optimism/src/libraries/Predeploys.sol";
+
+/// @title L2ToL2CrossDomainMessengerStub
+/// @notice Stand-in for the L2ToL2CrossDomainMessenger predeploy used by the Go test harness on simulated chains.
+///         It emits the same events and computes the same message hashes, without the sender context and
+///         reentrancy protection of the real messenger.
+contract L2ToL2CrossDomainMessengerStub {
+    /// @notice The nonce of the last message sent. Messages are numbered from 1 and stored under their nonce, the
+    ///         way GasTank.relayMessage reads the nested message hashes back.
+    uint256 public messageNonce;
+
+    /// @notice The hashes of the sent messages
+    mapping(uint256 nonce => bytes32 messageHash) public sentMessages;
+
+    /// @notice The relayed messages
+    mapping(bytes32 messageHash => bool relayed) public successfulMessages;
+
+    event SentMessage(
+        uint256 indexed destination, address indexed target, uint256 indexed messageNonce, address sender, bytes message
+    );
+
+    event RelayedMessage(
+        uint256 indexed source, uint256 indexed messageNonce, bytes32 indexed messageHash, bytes32 returnDataHash
+    );
+
+    error IdOriginNotL2ToL2CrossDomainMessenger();
+    error EventPayloadNotSentMessage();
+    error MessageDestinationNotRelayChain();
+    error MessageAlreadyRelayed();
+
+    /// @notice Sends a message to a destination chain
+    /// @param _destination The chain ID of the destination chain
+    /// @param _target The address called on the destination chain
+    /// @param _message The calldata of the call
+    /// @return messageHash_ The hash of the message
+    function sendMessage(uint256 _destination, address _target, bytes calldata _message)
+        external
+        returns (bytes32 messageHash_)
+    {
+        uint256 nonce = ++messageNonce;
+        messageHash_ =
+            Hashing.hashL2toL2CrossDomainMessage(_destination, block.chainid, nonce, msg.sender, _target, _message);
+        sentMessages[nonce] = messageHash_;
+
+        emit SentMessage(_destination, _target, nonce, msg.sender, _message);
+    }
+
+    /// @notice Relays a message sent to this chain
+    /// @param _id The identifier of the SentMessage log
+    /// @param _sentMessage The SentMessage log payload, its topics followed by its data
+    /// @return returnData_ The return data of the call to the target
+    function relayMessage(Identifier calldata _id, bytes calldata _sentMessage)
+        external
+        payable
+        returns (bytes memory returnData_)
+    {
+        if (_id.origin != Predeploys.L2_TO_L2_CROSS_DOMAIN_MESSENGER) revert IdOriginNotL2ToL2CrossDomainMessenger();
+
+        ICrossL2Inbox(Predeploys.CROSS_L2_INBOX).validateMessage(_id, keccak256(_sentMessage));
+
+        if (bytes32(_sentMessage[:32]) != SentMessage.selector) revert EventPayloadNotSentMessage();
+
+        (uint256 destination, address target, uint256 nonce) =
+            abi.decode(_sentMessage[32:128], (uint256, address, uint256));
+        if (destination != block.chainid) revert MessageDestinationNotRelayChain();
+
+        (address sender, bytes memory message) = abi.decode(_sentMessage[128:], (address, bytes));
+
+        bytes32 messageHash =
+            Hashing.hashL2toL2CrossDomainMessage(destination, _id.chainId, nonce, sender, target, message);
+        if (successfulMessages[messageHash]) revert MessageAlreadyRelayed();
+        successfulMessages[messageHash] = true;
+
+        bool success;
+        (success, returnData_) = target.call{value: msg.value}(message);
+        if (!success) {
+            assembly {
+                revert(add(returnData_, 32), mload(returnData_))
+            }
+        }
+
+        emit RelayedMessage(_id.chainId, nonce, messageHash, keccak256(returnData_));
+    }
+}